@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+	"text/tabwriter"
+)
+
+// runAlias Run the alias sub-command; list, add or remove.
+func runAlias(cfg *cli.Config, w io.Writer) error {
+	file := cfg.DataDir + cli.PS + cli.RegistryFile
+
+	reg, e1 := cli.LoadRegistry(file)
+	if e1 != nil {
+		return e1
+	}
+
+	switch cfg.SubCmdAlias.Method {
+	case "list":
+		return printAliases(reg, w)
+	case "add":
+		alias := &cli.Alias{
+			Answers: cfg.SubCmdAlias.Answers,
+			Ref:     cfg.SubCmdAlias.Ref,
+			Source:  cfg.SubCmdAlias.Source,
+			Type:    cfg.SubCmdAlias.Type,
+		}
+		if e := reg.Add(cfg.SubCmdAlias.Name, alias); e != nil {
+			return e
+		}
+		if e := reg.Save(file); e != nil {
+			return e
+		}
+		_, _ = fmt.Fprintf(w, "added %v\n", cfg.SubCmdAlias.Name)
+
+		return nil
+	case "remove":
+		if e := reg.Remove(cfg.SubCmdAlias.Name); e != nil {
+			return e
+		}
+		if e := reg.Save(file); e != nil {
+			return e
+		}
+		_, _ = fmt.Fprintf(w, "removed %v\n", cfg.SubCmdAlias.Name)
+
+		return nil
+	}
+
+	return fmt.Errorf(cli.Errors.BadAliasCmd, cfg.SubCmdAlias.Method)
+}
+
+// printAliases Print a table of the aliases.
+func printAliases(reg *cli.Registry, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "NAME\tSOURCE\tREF\tTYPE\tANSWERS")
+	for _, name := range reg.Names() {
+		a := reg.Aliases[name]
+		_, _ = fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\n", name, cli.RedactUrl(a.Source), a.Ref, a.Type, a.Answers)
+	}
+
+	return tw.Flush()
+}
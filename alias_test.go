@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAlias(tester *testing.T) {
+	dataDir := TmpDir + cli.PS + "alias-run"
+	_ = os.RemoveAll(dataDir)
+	_ = os.MkdirAll(dataDir, cli.DirMode)
+
+	var testCases = []struct {
+		name     string
+		method   string
+		alias    string
+		source   string
+		contains string
+		wantErr  bool
+	}{
+		{"add", "add", "web", "https://github.com/kohirens/tmpl-go-web.git", "added web", false},
+		{"badName", "add", "../web", "https://github.com/kohirens/tmpl-go-web.git", "", true},
+		{"list", "list", "", "", "web   https://github.com/kohirens/tmpl-go-web.git  2.1.0", false},
+		{"remove", "remove", "web", "", "removed web", false},
+		{"removeGone", "remove", "web", "", "", true},
+		{"bad", "rename", "", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cfg := &cli.Config{DataDir: dataDir}
+			cfg.SubCmdAlias.Method = tc.method
+			cfg.SubCmdAlias.Name = tc.alias
+			cfg.SubCmdAlias.Source = tc.source
+			cfg.SubCmdAlias.Ref = "2.1.0"
+			out := &bytes.Buffer{}
+
+			err := runAlias(cfg, out)
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error %v", err, tc.wantErr)
+			}
+
+			if !strings.Contains(out.String(), tc.contains) {
+				t.Errorf("got %q, want it to contain %q", out.String(), tc.contains)
+			}
+		})
+	}
+}
+
+func TestAlias(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+	tmplPath := test.SetupARepository(fixture, TmpDir+test.PS+"alias-remotes", FixtureDir, test.PS)
+	answers, _ := filepath.Abs(FixtureDir + test.PS + fixture + "-answers.json")
+
+	cmd := test.GetTestBinCmd([]string{cli.CmdAlias, "add", "-ref", "main", "-answer-path", answers, "repo07", tmplPath})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	// The ref and answers come from the alias.
+	outPath := TmpDir + test.PS + "processed" + test.PS + fixture + "-alias"
+	cmd = runMain(tester.Name(), []string{"-out-path", outPath, "repo07"})
+	out, _ = test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	if !stdlib.PathExist(outPath + test.PS + "README.md") {
+		tester.Errorf("got no template processed for the alias")
+	}
+
+	cmd = test.GetTestBinCmd([]string{cli.CmdConfig, "get", "alias.repo07.ref"})
+	out, _ = test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if !bytes.Contains(out, []byte("main")) {
+		tester.Errorf("got %s, want the ref of the alias", out)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+)
+
+// runAnswers Get a template like a normal run and write an answer file for it.
+func runAnswers(cfg *cli.Config, w io.Writer) error {
+	if e := resolveTemplate(cfg); e != nil {
+		return e
+	}
+
+	file := cfg.SubCmdAnswers.Out
+
+	changes, e1 := cli.WriteAnswers(file, cfg.TmplJson, cfg.SubCmdAnswers.Merge)
+	if e1 != nil {
+		return e1
+	}
+
+	_, _ = fmt.Fprintf(w, "wrote %v\n", file)
+	for _, name := range changes.Added {
+		_, _ = fmt.Fprintf(w, "  added    %v\n", name)
+	}
+	for _, name := range changes.Obsolete {
+		_, _ = fmt.Fprintf(w, "  obsolete %v\n", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAnswers(tester *testing.T) {
+	tmplDir := filepath.Join("internal", "cli", FixtureDir, "inspect-01")
+	file := TmpDir + cli.PS + "answers-init-01.json"
+	_ = os.Remove(file)
+
+	cfg := &cli.Config{Tmpl: tmplDir, TmplPath: tmplDir, TmplType: "dir", UsrOpts: &cli.UserOptions{}}
+	cfg.SubCmdAnswers.Out = file
+	out := &bytes.Buffer{}
+
+	if e := runAnswers(cfg, out); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if want := "  added    codeName\n"; !strings.Contains(out.String(), want) {
+		tester.Errorf("got %s, want it to contain %q", out, want)
+	}
+
+	aj, e1 := cli.LoadAnswers(file)
+	if e1 != nil {
+		tester.Fatalf("got an unexpected err: %s", e1)
+	}
+
+	if len(aj.Placeholders) != 3 {
+		tester.Errorf("got %v, want the 3 placeholders of the template", aj.Placeholders)
+	}
+}
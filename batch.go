@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// runBatch Run the template once for each row of the data set, the row is the
+// answers and fills in the out-path pattern; values, such as Data, are the same
+// for every row. Stops at the first row that fails unless continue-on-error is
+// set, then prints a summary. Row n is seeded with the seed plus n - 1, so no
+// two rows make the same values, such as passwords.
+func runBatch(cfg *cli.Config, fec *stdlib.FileExtChecker, values map[string]interface{}, w io.Writer) error {
+	rows, e1 := cli.ReadBatch(cfg.Batch)
+	if e1 != nil {
+		return e1
+	}
+
+	made := map[string]int{}
+	failed := 0
+	now := cli.Clock()
+
+	seed := cfg.Seed
+	if !cfg.SetFlags["seed"] {
+		s, e := cli.RandomSeed()
+		if e != nil {
+			return e
+		}
+		seed = s
+	}
+
+	for i, row := range rows {
+		n := i + 1
+
+		gen := cli.NewGenerator(seed+int64(i), nil)
+
+		out, e2 := batchRow(cfg, fec, values, row, n, made, now, gen)
+		if e2 != nil {
+			failed++
+			_, _ = fmt.Fprintf(w, "FAIL row %d: %v\n", n, e2)
+			if !cfg.ContinueOnError {
+				return fmt.Errorf(cli.Errors.BatchRowFailed, n, e2.Error())
+			}
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "ok   row %d: %v\n", n, out)
+	}
+
+	_, _ = fmt.Fprintf(w, "made %d of %d, %d failed\n", len(rows)-failed, len(rows), failed)
+
+	if failed > 0 {
+		return fmt.Errorf(cli.Errors.BatchFailed, failed, len(rows))
+	}
+
+	return nil
+}
+
+// batchRow Make the output of one row, answers from the answer file fill in
+// what the row does not have. Every row has the same time in its Meta.
+func batchRow(cfg *cli.Config, fec *stdlib.FileExtChecker, values map[string]interface{}, row map[string]string, n int, made map[string]int, now time.Time, gen *cli.Generator) (string, error) {
+	answers := map[string]string{}
+	for k, v := range cfg.AnswersJson.Placeholders {
+		answers[k] = v
+	}
+	for k, v := range row {
+		answers[k] = v
+	}
+
+	// There is no one to ask, so only the default value can fill in the rest.
+	missing := []string{}
+	for name := range cfg.TmplJson.Placeholders {
+		if _, ok := answers[name]; ok {
+			continue
+		}
+		if cfg.DefaultVal != " " {
+			answers[name] = cfg.DefaultVal
+			continue
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf(cli.Errors.BatchMissingAnswers, strings.Join(missing, ", "))
+	}
+
+	out, e1 := cli.BatchOutPath(cfg.OutPath, answers)
+	if e1 != nil {
+		return "", e1
+	}
+
+	if prev, ok := made[out]; ok {
+		return "", fmt.Errorf(cli.Errors.BatchOutPathTaken, out, prev)
+	}
+	made[out] = n
+
+	if stdlib.PathExist(out) {
+		return "", fmt.Errorf(cli.Messages.OutPathExist, out)
+	}
+
+	rowValues := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		rowValues[k] = v
+	}
+	rowValues[cli.MetaKey] = cli.NewMeta(cfg, out, now)
+
+	if e := cli.ParseDir(cfg.Tmpl, out, answers, fec, cfg.TmplJson.Excludes, cfg.TmplJson.Skip, rowValues, gen); e != nil {
+		return "", e
+	}
+
+	r := cli.NewRecord(cfg)
+	r.Placeholders = answers
+	r.SetGenerated(gen, cfg.RecordSecrets)
+
+	return out, cli.WriteRecord(out, r)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBatch(tester *testing.T) {
+	var testCases = []struct {
+		name     string
+		code     int
+		args     []string
+		made     []string
+		notMade  []string
+		contains string
+	}{
+		{
+			"csv",
+			0,
+			[]string{"-batch", FixtureDir + cli.PS + "batch-01.csv"},
+			[]string{"acme", "globex"},
+			nil,
+			"made 2 of 2, 0 failed",
+		},
+		{
+			"stopOnError",
+			1,
+			[]string{"-batch", FixtureDir + cli.PS + "batch-02.jsonl"},
+			[]string{"acme"},
+			[]string{"42"},
+			"FAIL row 2: no value for appName",
+		},
+		{
+			"continueOnError",
+			1,
+			[]string{"-batch", FixtureDir + cli.PS + "batch-02.jsonl", "-continue-on-error"},
+			[]string{"acme", "42"},
+			nil,
+			"made 2 of 3, 1 failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			outDir := TmpDir + cli.PS + "batch-" + tc.name
+			_ = os.RemoveAll(outDir)
+
+			args := append(tc.args,
+				"-tmpl-path", FixtureDir+cli.PS+"parse-dir-02",
+				"-out-path", outDir+"/{{.clientId}}",
+				"-tmpl-type", "dir",
+			)
+			cmd := runMain(tester.Name(), args)
+			out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+			if got := cmd.ProcessState.ExitCode(); got != tc.code {
+				t.Errorf("got %v, want %v: %s", got, tc.code, out)
+			}
+
+			if !strings.Contains(string(out), tc.contains) {
+				t.Errorf("got %s, want it to contain %q", out, tc.contains)
+			}
+
+			for _, d := range tc.made {
+				if !stdlib.PathExist(outDir + cli.PS + d + cli.PS + cli.RecordFile) {
+					t.Errorf("got no output for %v, want it made", d)
+				}
+			}
+
+			for _, d := range tc.notMade {
+				if stdlib.PathExist(outDir + cli.PS + d) {
+					t.Errorf("got output for %v, want the batch stopped before it", d)
+				}
+			}
+		})
+	}
+}
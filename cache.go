@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// runCache Run the cache sub-command; list, info, prune, clear or path.
+func runCache(cfg *cli.Config, w io.Writer) error {
+	cacheDir := cfg.UsrOpts.CacheDir
+
+	switch cfg.SubCmdCache.Method {
+	case "list":
+		entries, e1 := cli.ListCache(cacheDir)
+		if e1 != nil {
+			return e1
+		}
+		return printCacheList(entries, w)
+	case "info":
+		entries, e1 := cli.FindCacheEntries(cacheDir, cfg.SubCmdCache.Source)
+		if e1 != nil {
+			return e1
+		}
+		cli.MeasureCache(cacheDir, entries)
+		return printCacheInfo(cacheDir, entries, w)
+	case "prune":
+		return pruneCache(cfg, w)
+	case "clear":
+		if cfg.SubCmdCache.Source == "" {
+			return cli.ClearCache(cacheDir)
+		}
+
+		entries, e1 := cli.FindCacheEntries(cacheDir, cfg.SubCmdCache.Source)
+		if e1 != nil {
+			return e1
+		}
+
+		for _, ce := range entries {
+			removed, e := cli.RemoveCacheEntry(cacheDir, ce)
+			if e != nil {
+				return e
+			}
+
+			if removed {
+				_, _ = fmt.Fprintf(w, "removed %v\n", ce.Name)
+			}
+		}
+
+		return nil
+	case "path":
+		_, e1 := fmt.Fprintln(w, cacheDir)
+		return e1
+	}
+
+	return fmt.Errorf(cli.Errors.BadCacheCmd, cfg.SubCmdCache.Method)
+}
+
+// printCacheList Print a table of cache entries.
+func printCacheList(entries []*cli.CacheEntry, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(tw, "SOURCE\tREF\tCOMMIT\tSIZE\tLAST USED")
+	for _, ce := range entries {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%v\t%v\t%v\t%v\t%v\n",
+			ce.Source,
+			ce.Ref,
+			shortCommit(ce.Commit),
+			cli.FormatSize(ce.Size),
+			ce.LastUsed.Local().Format(time.DateTime),
+		)
+	}
+
+	return tw.Flush()
+}
+
+// printCacheInfo Print all there is to know about cache entries.
+func printCacheInfo(cacheDir string, entries []*cli.CacheEntry, w io.Writer) error {
+	for i, ce := range entries {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)
+		_, _ = fmt.Fprintf(tw, "Source:\t%v\n", ce.Source)
+		_, _ = fmt.Fprintf(tw, "Type:\t%v\n", ce.Type)
+		if ce.Ref != "" {
+			_, _ = fmt.Fprintf(tw, "Ref:\t%v\n", ce.Ref)
+		}
+		if ce.Commit != "" {
+			_, _ = fmt.Fprintf(tw, "Commit:\t%v\n", ce.Commit)
+		}
+		_, _ = fmt.Fprintf(tw, "Path:\t%v\n", cacheDir+cli.PS+ce.Name)
+		_, _ = fmt.Fprintf(tw, "Size:\t%v\n", cli.FormatSize(ce.Size))
+		_, _ = fmt.Fprintf(tw, "Created:\t%v\n", ce.Created.Local().Format(time.DateTime))
+		_, _ = fmt.Fprintf(tw, "Last used:\t%v\n", ce.LastUsed.Local().Format(time.DateTime))
+
+		if e := tw.Flush(); e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// pruneCache Remove templates by age and to fit the size budget.
+func pruneCache(cfg *cli.Config, w io.Writer) error {
+	if cfg.SubCmdCache.OlderThan == "" && cfg.SubCmdCache.MaxSize == "" {
+		return fmt.Errorf(cli.Errors.NoPruneLimit)
+	}
+
+	var maxAge time.Duration
+	var maxSize int64
+
+	if cfg.SubCmdCache.OlderThan != "" {
+		age, e1 := cli.ParseAge(cfg.SubCmdCache.OlderThan)
+		if e1 != nil {
+			return e1
+		}
+		maxAge = age
+	}
+
+	if cfg.SubCmdCache.MaxSize != "" {
+		size, e2 := cli.ParseSize(cfg.SubCmdCache.MaxSize)
+		if e2 != nil {
+			return e2
+		}
+		maxSize = size
+	}
+
+	removed, e3 := cli.PruneCache(cfg.UsrOpts.CacheDir, maxAge, maxSize, time.Now())
+
+	var freed int64
+	for _, ce := range removed {
+		freed += ce.Size
+		_, _ = fmt.Fprintf(w, "removed %v %v\n", ce.Source, ce.Ref)
+	}
+	_, _ = fmt.Fprintf(w, "freed %v\n", cli.FormatSize(freed))
+
+	return e3
+}
+
+// cachedDownload Return the most recently used download of a zip template in
+// the cache, checked against sum when it is given.
+func cachedDownload(cacheDir, source, sum string) (string, error) {
+	if _, fragSum := cli.ParseChecksumFragment(source); sum == "" {
+		sum = fragSum
+	}
+
+	entries, _ := cli.FindCacheEntries(cacheDir, source)
+
+	for _, ce := range entries {
+		zipFile := cacheDir + cli.PS + ce.Name
+		if ce.Type != "zip" || !stdlib.PathExist(zipFile) {
+			continue
+		}
+
+		if sum != "" {
+			if e := cli.VerifyChecksum(zipFile, sum); e != nil {
+				return "", e
+			}
+		}
+
+		infof(cli.Messages.UsingCachedDownload, zipFile)
+
+		return zipFile, nil
+	}
+
+	return "", fmt.Errorf(cli.Errors.NotCachedZip, cli.RedactUrl(source))
+}
+
+// cachedRefs Return the refs of a git template in the cache, the most
+// recently used first.
+func cachedRefs(cacheDir, source string) []string {
+	entries, _ := cli.FindCacheEntries(cacheDir, source)
+
+	refs := make([]string, 0, len(entries))
+	for _, ce := range entries {
+		if ce.Type == "git" {
+			refs = append(refs, ce.Ref)
+		}
+	}
+
+	return refs
+}
+
+// notCachedError Explain that a ref of a git template is not in the cache,
+// and which refs are.
+func notCachedError(cacheDir, source, ref string) error {
+	refs := "none"
+	if cached := cachedRefs(cacheDir, source); len(cached) > 0 {
+		refs = strings.Join(cached, ", ")
+	}
+
+	return fmt.Errorf(cli.Errors.NotCached, cli.RedactUrl(source), ref, refs)
+}
+
+// touchCache Record the use of a cache entry. A failure is only logged, the
+// template can still be used.
+func touchCache(cacheDir string, ce *cli.CacheEntry) {
+	if e := cli.TouchCacheEntry(cacheDir, ce); e != nil {
+		logf(cli.Messages.CacheNotRecorded, e.Error())
+	}
+}
+
+// shortCommit Abbreviate a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+
+	return commit
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunCache(tester *testing.T) {
+	cacheDir := TmpDir + cli.PS + "cache-run"
+	_ = os.MkdirAll(cacheDir+cli.PS+"web-main", cli.DirMode)
+	_ = cli.TouchCacheEntry(cacheDir, &cli.CacheEntry{
+		Commit: "b437757d8da54ada2e622996af0911ac5697242b",
+		Name:   "web-main",
+		Ref:    "main",
+		Source: "https://example.com/org/web.git",
+		Type:   "git",
+	})
+
+	var testCases = []struct {
+		name     string
+		method   string
+		source   string
+		contains string
+		wantErr  bool
+	}{
+		{"list", "list", "", "https://example.com/org/web.git  main  b437757d8da5", false},
+		{"info", "info", "https://example.com/org/web.git", "Commit:    b437757d8da54ada2e622996af0911ac5697242b", false},
+		{"infoNotFound", "info", "https://example.com/org/app.git", "", true},
+		{"path", "path", "", cacheDir + "\n", false},
+		{"pruneNoLimit", "prune", "", "", true},
+		{"clear", "clear", "web-main", "removed web-main", false},
+		{"bad", "purge", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cfg := &cli.Config{UsrOpts: &cli.UserOptions{CacheDir: cacheDir}}
+			cfg.SubCmdCache.Method = tc.method
+			cfg.SubCmdCache.Source = tc.source
+			out := &bytes.Buffer{}
+
+			err := runCache(cfg, out)
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error %v", err, tc.wantErr)
+			}
+
+			if !strings.Contains(out.String(), tc.contains) {
+				t.Errorf("got %q, want it to contain %q", out.String(), tc.contains)
+			}
+		})
+	}
+
+	if _, e := os.Stat(cacheDir + cli.PS + "web-main"); !os.IsNotExist(e) {
+		tester.Error("web-main was not cleared from the cache")
+	}
+}
+
+func TestCachedDownload(tester *testing.T) {
+	cacheDir := TmpDir + cli.PS + "cache-download"
+	_ = os.MkdirAll(cacheDir, cli.DirMode)
+	source := "https://example.com/web.zip"
+	zipFile := cacheDir + cli.PS + cli.DownloadName(source)
+	_ = os.WriteFile(zipFile, []byte("zip"), 0644)
+	_ = cli.TouchCacheEntry(cacheDir, &cli.CacheEntry{Name: cli.DownloadName(source), Source: source, Type: "zip"})
+	// Not the sha256 of the cached content.
+	sum := "a3a3c4a9f1b63d0f3d04ff0d3d29a4a0a3f1a3f6b1d1c2e9fbc2dbeeb1c53a8c"
+
+	var testCases = []struct {
+		name    string
+		source  string
+		sum     string
+		want    string
+		wantErr bool
+	}{
+		{"cached", source, "", zipFile, false},
+		{"badSum", source, sum, "", true},
+		{"notCached", "https://example.com/app.zip", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := cachedDownload(cacheDir, tc.source, tc.sum)
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDataFlag(tester *testing.T) {
+	fixtures := filepath.Join("internal", "cli", FixtureDir)
+	outDir := TmpDir + cli.PS + "app-data-01"
+	_ = os.RemoveAll(outDir)
+
+	cmd := runMain(tester.Name(), []string{
+		"-data", "regions=" + filepath.Join(fixtures, "regions-02.yaml"),
+		"-default-val", "Web",
+		"-tmpl-path", filepath.Join(fixtures, "data-01"),
+		"-out-path", outDir,
+		"-tmpl-type", "dir",
+	})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	readme, _ := os.ReadFile(outDir + cli.PS + "README.md")
+	if want := "* ap-south-1\n\nOwned by web."; !strings.Contains(string(readme), want) {
+		tester.Errorf("got %s, want it to contain %q", readme, want)
+	}
+
+	if stdlib.PathExist(outDir + cli.PS + "data") {
+		tester.Errorf("got the data files in the output, want them left out")
+	}
+}
@@ -10,29 +10,100 @@ import (
 	"github.com/kohirens/stdlib/log"
 	"github.com/kohirens/tmpltoapp/internal/cli"
 	"os"
+	"strings"
 	"text/template"
 )
 
 // define All application flags.
 func defineFlags(cfg *cli.Config) {
+	cfg.SubCmdAlias.FlagSet = flag.NewFlagSet(cli.CmdAlias, flag.ExitOnError)
+	cfg.SubCmdAlias.FlagSet.StringVar(&cfg.SubCmdAlias.Answers, "answer-path", "", usageMsgs["answer-path"])
+	cfg.SubCmdAlias.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdAlias.FlagSet.StringVar(&cfg.SubCmdAlias.Ref, "ref", "", usageMsgs["ref"])
+	cfg.SubCmdAlias.FlagSet.StringVar(&cfg.SubCmdAlias.Type, "tmpl-type", "", usageMsgs["tmpl-type"])
+	cfg.SubCmdAlias.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
 	// Note: These are defined in alphabetical order.
 	flag.StringVar(&cfg.AnswersPath, "answer-path", "", usageMsgs["answer-path"])
+	flag.StringVar(&cfg.Batch, "batch", "", usageMsgs["batch"])
 	flag.StringVar(&cfg.Branch, "branch", "main", usageMsgs["branch"])
+	flag.BoolVar(&cfg.ContinueOnError, "continue-on-error", false, usageMsgs["continue-on-error"])
+	flag.Var(&cfg.DataFiles, "data", usageMsgs["data"])
 	flag.StringVar(&cfg.DefaultVal, "default-val", " ", usageMsgs["default-val"])
 	flag.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
 	flag.BoolVar(&cfg.Help, "h", false, usageMsgs["help"]+" (shorthand)")
+	flag.BoolVar(&cfg.Offline, "offline", false, usageMsgs["offline"])
 	flag.StringVar(&cfg.OutPath, "out-path", "", usageMsgs["out-path"])
+	flag.BoolVar(&cfg.Pre, "pre", false, usageMsgs["pre"])
+	flag.BoolVar(&cfg.RecordSecrets, "record-secrets", false, usageMsgs["record-secrets"])
+	flag.BoolVar(&cfg.RecurseSubmodules, "recurse-submodules", false, usageMsgs["recurse-submodules"])
+	flag.StringVar(&cfg.Branch, "ref", "main", usageMsgs["ref"])
+	flag.BoolVar(&cfg.RequireSignature, "require-signature", false, usageMsgs["require-signature"])
+	flag.Int64Var(&cfg.Seed, "seed", 0, usageMsgs["seed"])
+	flag.StringVar(&cfg.Sha256, "sha256", "", usageMsgs["sha256"])
 	flag.StringVar(&cfg.TmplPath, "tmpl-path", "", usageMsgs["tmpl-path"])
 	flag.StringVar(&cfg.TmplType, "tmpl-type", "git", usageMsgs["tmpl-type"])
+	flag.StringVar(&cfg.TmplVersion, "tmpl-version", "", usageMsgs["tmpl-version"])
 	flag.IntVar(&verbosityLevel, "verbosity", 0, usageMsgs["verbosity"])
 	flag.BoolVar(&cfg.Version, "version", false, usageMsgs["version"])
+	cfg.SubCmdAnswers.FlagSet = flag.NewFlagSet(cli.CmdAnswers, flag.ExitOnError)
+	cfg.SubCmdAnswers.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdAnswers.FlagSet.BoolVar(&cfg.SubCmdAnswers.Merge, "merge", false, usageMsgs["merge"])
+	cfg.SubCmdAnswers.FlagSet.StringVar(&cfg.SubCmdAnswers.Out, "o", "answers.json", usageMsgs["o"])
+	cfg.SubCmdAnswers.FlagSet.StringVar(&cfg.Branch, "ref", "main", usageMsgs["ref"])
+	cfg.SubCmdAnswers.FlagSet.StringVar(&cfg.TmplType, "tmpl-type", "git", usageMsgs["tmpl-type"])
+	cfg.SubCmdAnswers.FlagSet.StringVar(&cfg.TmplVersion, "tmpl-version", "", usageMsgs["tmpl-version"])
+	cfg.SubCmdAnswers.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
+	cfg.SubCmdCache.FlagSet = flag.NewFlagSet(cli.CmdCache, flag.ExitOnError)
+	cfg.SubCmdCache.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdCache.FlagSet.StringVar(&cfg.SubCmdCache.MaxSize, "max-size", "", usageMsgs["max-size"])
+	cfg.SubCmdCache.FlagSet.StringVar(&cfg.SubCmdCache.OlderThan, "older-than", "", usageMsgs["older-than"])
+	cfg.SubCmdCache.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
 	cfg.SubCmdConfig.FlagSet = flag.NewFlagSet(cli.CmdConfig, flag.ExitOnError)
 	cfg.SubCmdConfig.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdInitTemplate.FlagSet = flag.NewFlagSet(cli.CmdInitTemplate, flag.ExitOnError)
+	cfg.SubCmdInitTemplate.FlagSet.StringVar(&cfg.SubCmdInitTemplate.From, "from", "", usageMsgs["from"])
+	cfg.SubCmdInitTemplate.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdInitTemplate.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
+	cfg.SubCmdInspect.FlagSet = flag.NewFlagSet(cli.CmdInspect, flag.ExitOnError)
+	cfg.SubCmdInspect.FlagSet.StringVar(&cfg.SubCmdInspect.Format, "format", "text", usageMsgs["format"])
+	cfg.SubCmdInspect.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdInspect.FlagSet.StringVar(&cfg.Branch, "ref", "main", usageMsgs["ref"])
+	cfg.SubCmdInspect.FlagSet.StringVar(&cfg.TmplType, "tmpl-type", "git", usageMsgs["tmpl-type"])
+	cfg.SubCmdInspect.FlagSet.StringVar(&cfg.TmplVersion, "tmpl-version", "", usageMsgs["tmpl-version"])
+	cfg.SubCmdInspect.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
 	cfg.SubCmdManifest.FlagSet = flag.NewFlagSet(cli.CmdManifest, flag.ExitOnError)
 	cfg.SubCmdManifest.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
 	cfg.SubCmdManifest.FlagSet.Usage = func() {
 		Usage(cfg)
 	}
+	cfg.SubCmdMirror.FlagSet = flag.NewFlagSet(cli.CmdMirror, flag.ExitOnError)
+	cfg.SubCmdMirror.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdMirror.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
+	cfg.SubCmdTest.FlagSet = flag.NewFlagSet(cli.CmdTest, flag.ExitOnError)
+	cfg.SubCmdTest.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdTest.FlagSet.BoolVar(&cfg.SubCmdTest.Update, "update", false, usageMsgs["update"])
+	cfg.SubCmdTest.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
+	cfg.SubCmdVersions.FlagSet = flag.NewFlagSet(cli.CmdVersions, flag.ExitOnError)
+	cfg.SubCmdVersions.FlagSet.BoolVar(&cfg.Help, "help", false, usageMsgs["help"])
+	cfg.SubCmdVersions.FlagSet.BoolVar(&cfg.Pre, "pre", false, usageMsgs["pre"])
+	cfg.SubCmdVersions.FlagSet.StringVar(&cfg.TmplVersion, "tmpl-version", "", usageMsgs["tmpl-version"])
+	cfg.SubCmdVersions.FlagSet.Usage = func() {
+		Usage(cfg)
+	}
 }
 
 // Parse Process and validate all CLI flags.
@@ -43,6 +114,12 @@ func parseFlags(cfg *cli.Config) error {
 	// and then arguments; it may also require less code to debug and document for not very much gain.
 	flag.Parse()
 
+	// Remember the flags given, an alias only fills in those that were not.
+	cfg.SetFlags = map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		cfg.SetFlags[f.Name] = true
+	})
+
 	infof(cli.Messages.VerboseLevelInfo, verbosityLevel)
 
 	pArgs := flag.Args()
@@ -59,10 +136,26 @@ func parseFlags(cfg *cli.Config) error {
 	// process sub-commands
 	if len(pArgs) > 0 {
 		switch pArgs[0] {
+		case cli.CmdAlias:
+			return parseAliasCmd(cfg, pArgs[1:])
+		case cli.CmdAnswers:
+			return parseAnswersCmd(cfg, pArgs[1:])
+		case cli.CmdCache:
+			return parseCacheCmd(cfg, pArgs[1:])
 		case cli.CmdConfig:
 			return parseSubCmd(cfg, pArgs[1:])
+		case cli.CmdInitTemplate:
+			return parseInitTemplateCmd(cfg, pArgs[1:])
+		case cli.CmdInspect:
+			return parseInspectCmd(cfg, pArgs[1:])
 		case cli.CmdManifest:
 			return parseManifestCmd(cfg, pArgs[1:])
+		case cli.CmdMirror:
+			return parseMirrorCmd(cfg, pArgs[1:])
+		case cli.CmdTest:
+			return parseTestCmd(cfg, pArgs[1:])
+		case cli.CmdVersions:
+			return parseVersionsCmd(cfg, pArgs[1:])
 		}
 	}
 
@@ -101,6 +194,131 @@ func parseFlags(cfg *cli.Config) error {
 	return nil
 }
 
+// parseAliasCmd Parse the alias sub-command flags/options/args.
+func parseAliasCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdAlias
+	if len(osArgs) > 0 && !strings.HasPrefix(osArgs[0], "-") {
+		cfg.SubCmdAlias.Method = osArgs[0]
+		osArgs = osArgs[1:]
+	}
+
+	if e := cfg.SubCmdAlias.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdAlias.FlagSet.Args()
+
+	switch cfg.SubCmdAlias.Method {
+	case "list":
+	case "add":
+		if len(args) < 2 {
+			Usage(cfg)
+			return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdAlias+" add", 2)
+		}
+		cfg.SubCmdAlias.Name = args[0]
+		cfg.SubCmdAlias.Source = args[1]
+	case "remove":
+		if len(args) < 1 {
+			Usage(cfg)
+			return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdAlias+" remove", 1)
+		}
+		cfg.SubCmdAlias.Name = args[0]
+	default:
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.BadAliasCmd, cfg.SubCmdAlias.Method)
+	}
+
+	log.Dbugf("cfg.SubCmdAlias.Method = %v\n", cfg.SubCmdAlias.Method)
+	log.Dbugf("cfg.SubCmdAlias.Name = %v\n", cfg.SubCmdAlias.Name)
+
+	return nil
+}
+
+// parseAnswersCmd Parse the answers sub-command flags/options/args.
+func parseAnswersCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdAnswers
+	if len(osArgs) > 0 && !strings.HasPrefix(osArgs[0], "-") {
+		cfg.SubCmdAnswers.Method = osArgs[0]
+		osArgs = osArgs[1:]
+	}
+
+	if e := cfg.SubCmdAnswers.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	// A -ref or -tmpl-type given here keeps the one of an alias from being used.
+	cfg.SubCmdAnswers.FlagSet.Visit(func(f *flag.Flag) {
+		cfg.SetFlags[f.Name] = true
+	})
+
+	if cfg.SubCmdAnswers.Method != "init" {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.BadAnswersCmd, cfg.SubCmdAnswers.Method)
+	}
+
+	args := cfg.SubCmdAnswers.FlagSet.Args()
+	if len(args) < 1 {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdAnswers+" init", 1)
+	}
+
+	cfg.TmplPath = args[0]
+
+	log.Dbugf("cfg.TmplPath = %v\n", cfg.TmplPath)
+	log.Dbugf("cfg.SubCmdAnswers.Out = %v\n", cfg.SubCmdAnswers.Out)
+
+	return nil
+}
+
+// parseCacheCmd Parse the cache sub-command flags/options/args.
+func parseCacheCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdCache
+	if len(osArgs) > 0 && !strings.HasPrefix(osArgs[0], "-") {
+		cfg.SubCmdCache.Method = osArgs[0]
+		osArgs = osArgs[1:]
+	}
+
+	if e := cfg.SubCmdCache.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdCache.FlagSet.Args()
+
+	switch cfg.SubCmdCache.Method {
+	case "list", "path", "prune":
+	case "clear":
+		if len(args) > 0 {
+			cfg.SubCmdCache.Source = args[0]
+		}
+	case "info":
+		if len(args) < 1 {
+			Usage(cfg)
+			return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdCache+" info", 1)
+		}
+		cfg.SubCmdCache.Source = args[0]
+	default:
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.BadCacheCmd, cfg.SubCmdCache.Method)
+	}
+
+	log.Dbugf("cfg.SubCmdCache.Method = %v\n", cfg.SubCmdCache.Method)
+	log.Dbugf("cfg.SubCmdCache.Source = %v\n", cfg.SubCmdCache.Source)
+
+	return nil
+}
+
 // parseSubCmd Parse the sub-command flags/options/args.
 func parseSubCmd(cfg *cli.Config, osArgs []string) error {
 	if e := cfg.SubCmdConfig.FlagSet.Parse(osArgs); e != nil {
@@ -157,6 +375,105 @@ func parseManifestCmd(cfg *cli.Config, osArgs []string) error {
 	return nil
 }
 
+// parseInitTemplateCmd Parse the init-template sub-command flags/options/args.
+func parseInitTemplateCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdInitTemplate
+	if e := cfg.SubCmdInitTemplate.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdInitTemplate.FlagSet.Args()
+	if len(args) < 1 {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdInitTemplate, 1)
+	}
+
+	cfg.SubCmdInitTemplate.Dir = args[0]
+
+	log.Dbugf("cfg.SubCmdInitTemplate.Dir = %v\n", cfg.SubCmdInitTemplate.Dir)
+	log.Dbugf("cfg.SubCmdInitTemplate.From = %v\n", cfg.SubCmdInitTemplate.From)
+
+	return nil
+}
+
+// parseInspectCmd Parse the inspect sub-command flags/options/args.
+func parseInspectCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdInspect
+	if e := cfg.SubCmdInspect.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	// Record the flags given, so inspect shows the template asked for.
+	cfg.SubCmdInspect.FlagSet.Visit(func(f *flag.Flag) {
+		cfg.SetFlags[f.Name] = true
+	})
+
+	if f := cfg.SubCmdInspect.Format; f != "text" && f != "json" {
+		return fmt.Errorf(cli.Errors.BadFormat, f)
+	}
+
+	args := cfg.SubCmdInspect.FlagSet.Args()
+	if len(args) < 1 {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdInspect, 1)
+	}
+
+	cfg.TmplPath = args[0]
+
+	log.Dbugf("cfg.TmplPath = %v\n", cfg.TmplPath)
+
+	return nil
+}
+
+// parseMirrorCmd Parse the mirror sub-command flags/options/args.
+func parseMirrorCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdMirror
+	if len(osArgs) > 0 && !strings.HasPrefix(osArgs[0], "-") {
+		cfg.SubCmdMirror.Method = osArgs[0]
+		osArgs = osArgs[1:]
+	}
+
+	if e := cfg.SubCmdMirror.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdMirror.FlagSet.Args()
+
+	switch cfg.SubCmdMirror.Method {
+	case "export", "import":
+		if len(args) < 1 {
+			Usage(cfg)
+			return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdMirror+" "+cfg.SubCmdMirror.Method, 1)
+		}
+		cfg.SubCmdMirror.File = args[0]
+	default:
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.BadMirrorCmd, cfg.SubCmdMirror.Method)
+	}
+
+	// Only an export takes the templates to pack.
+	if cfg.SubCmdMirror.Method == "export" {
+		cfg.SubCmdMirror.Sources = args[1:]
+	}
+
+	log.Dbugf("cfg.SubCmdMirror.Method = %v\n", cfg.SubCmdMirror.Method)
+	log.Dbugf("cfg.SubCmdMirror.File = %v\n", cfg.SubCmdMirror.File)
+
+	return nil
+}
+
 // subCmdConfigUsage print config command usage
 func subCmdConfigUsage(cfg *cli.Config) {
 	fmt.Printf("usage: config set|get <args>\n\n")
@@ -165,29 +482,110 @@ func subCmdConfigUsage(cfg *cli.Config) {
 	fmt.Printf("\tconfig get \"CacheDir\"\n\n")
 	fmt.Printf("Settings: \n")
 	fmt.Printf("\tCacheDir - Path to store template downloaded\n")
-	fmt.Printf("\tExcludeFileExtensions - Files ending with these extensions will be excluded from parsing and copied as-is\n\n")
+	fmt.Printf("\tExcludeFileExtensions - Files ending with these extensions will be excluded from parsing and copied as-is\n")
+	fmt.Printf("\tTrustedKeys - Comma separated ed25519/minisign public keys, or paths to them, trusted to sign templates\n")
+	fmt.Printf("\tAllowList - Comma separated patterns of template sources that are allowed, for example \"github.com/my-org/*\"\n")
+	fmt.Printf("\tDenyList - Comma separated patterns of template sources that are never allowed\n")
+	fmt.Printf("\tGitBackend - Run the git binary (exec), or Git built-in (go); auto (the default) uses exec when git is installed\n")
+	fmt.Printf("\tSshCommand - Command git uses to reach SSH remotes, like GIT_SSH_COMMAND\n")
+	fmt.Printf("\tSshKey - Path to a private key for SSH remotes, used when SshCommand is not set\n")
+	fmt.Printf("\tOffline - true to only use templates from the cache, like -offline\n")
+	fmt.Printf("\talias.<name> - Template location of an alias, saved in registry.json; empty removes it\n")
+	fmt.Printf("\talias.<name>.ref, alias.<name>.answers, alias.<name>.type - Ref, answer file and template type of an alias\n")
+	fmt.Printf("\tPolicy - (get only) The allow/deny policy in effect, including the system-wide policy\n\n")
 	fmt.Printf("Options: \n")
 	// print options usage
 	cfg.SubCmdConfig.FlagSet.VisitAll(func(f *flag.Flag) {
 		um, ok := usageMsgs[f.Name]
 		if ok {
 			fmt.Printf("  -%-11s %v\n\n", f.Name, um)
-			f.Value.String()
 		}
 	})
 }
 
+// parseTestCmd Parse the test sub-command flags/options/args.
+func parseTestCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdTest
+	if e := cfg.SubCmdTest.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdTest.FlagSet.Args()
+	if len(args) < 1 {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdTest, 1)
+	}
+
+	cfg.SubCmdTest.Dir = args[0]
+
+	log.Dbugf("cfg.SubCmdTest.Dir = %v\n", cfg.SubCmdTest.Dir)
+
+	return nil
+}
+
+// parseVersionsCmd Parse the versions sub-command flags/options/args.
+func parseVersionsCmd(cfg *cli.Config, osArgs []string) error {
+	cfg.SubCmd = cli.CmdVersions
+	if e := cfg.SubCmdVersions.FlagSet.Parse(osArgs); e != nil {
+		return fmt.Errorf(cli.Errors.ParsingConfigArgs, e.Error())
+	}
+
+	if cfg.Help {
+		return nil
+	}
+
+	args := cfg.SubCmdVersions.FlagSet.Args()
+	if len(args) < 1 {
+		Usage(cfg)
+		return fmt.Errorf(cli.Errors.InvalidNoSubCmdArgs, cli.CmdVersions, 1)
+	}
+
+	cfg.TmplPath = args[0]
+
+	log.Dbugf("cfg.TmplPath = %v\n", cfg.TmplPath)
+
+	return nil
+}
+
 // Usage Print app usage documentation.
 func Usage(cfg *cli.Config) error {
 	tmpl := template.New("usage")
 
 	switch cfg.SubCmd {
+	case cli.CmdAlias:
+		template.Must(tmpl.Parse(usageAlias))
+		return UsageTmpl(cfg, tmpl)
+	case cli.CmdAnswers:
+		template.Must(tmpl.Parse(usageAnswers))
+		return UsageTmpl(cfg, tmpl)
+	case cli.CmdCache:
+		template.Must(tmpl.Parse(usageCache))
+		return UsageTmpl(cfg, tmpl)
 	case cli.CmdConfig:
 		subCmdConfigUsage(cfg)
 		return nil
+	case cli.CmdInitTemplate:
+		template.Must(tmpl.Parse(usageInitTemplate))
+		return UsageTmpl(cfg, tmpl)
+	case cli.CmdInspect:
+		template.Must(tmpl.Parse(usageInspect))
+		return UsageTmpl(cfg, tmpl)
 	case cli.CmdManifest:
 		template.Must(tmpl.Parse(usageManifest))
 		return UsageTmpl(cfg, tmpl)
+	case cli.CmdMirror:
+		template.Must(tmpl.Parse(usageMirror))
+		return UsageTmpl(cfg, tmpl)
+	case cli.CmdTest:
+		template.Must(tmpl.Parse(usageTest))
+		return UsageTmpl(cfg, tmpl)
+	case cli.CmdVersions:
+		template.Must(tmpl.Parse(usageVersions))
+		return UsageTmpl(cfg, tmpl)
 	}
 
 	uTmplData := map[string]string{
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// gitExec Runs the git binary for all Git operations.
+type gitExec struct{}
+
+// Bundle A shallow clone is deepened first, a bundle of it could not be
+// cloned.
+func (g *gitExec) Bundle(repoDir, file string) error {
+	shallow, e1 := gitCmd(repoDir, "rev-parse", "--is-shallow-repository")
+	if e1 != nil {
+		return e1
+	}
+
+	if strings.TrimSpace(string(shallow)) == "true" {
+		if _, e := gitCmd(repoDir, "fetch", "--unshallow", "--tags"); e != nil {
+			return e
+		}
+	}
+
+	_, e2 := gitCmd(repoDir, "bundle", "create", file, "--all")
+
+	return e2
+}
+
+func (g *gitExec) Checkout(repoDir, ref string) error {
+	co, e1 := gitCmd(repoDir, "checkout", ref)
+	if e1 != nil {
+		return e1
+	}
+
+	infof("checkout output \n%s", co)
+
+	return nil
+}
+
+func (g *gitExec) Clone(repoUri, repoDir, branch string, depth int) error {
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+
+	sco, e1 := gitCmd(".", append(args, repoUri, repoDir)...)
+	if e1 != nil {
+		return e1
+	}
+
+	infof("clone output \n%s", sco)
+
+	return nil
+}
+
+func (g *gitExec) Fetch(repoDir string) error {
+	_, e1 := gitCmd(repoDir, "fetch", "--all", "--tags", "-p")
+
+	return e1
+}
+
+func (g *gitExec) FetchCommit(repoUri, repoDir, commit string, depth int) error {
+	if _, e1 := gitCmd(".", "init", "--quiet", repoDir); e1 != nil {
+		return e1
+	}
+
+	if _, e2 := gitCmd(repoDir, "remote", "add", "origin", repoUri); e2 != nil {
+		return e2
+	}
+
+	args := []string{"fetch"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+
+	if _, e3 := gitCmd(repoDir, append(args, "origin", commit)...); e3 != nil {
+		return e3
+	}
+
+	return g.Checkout(repoDir, commit)
+}
+
+// LfsPull Needs git-lfs, which is installed separately from git. Submodules
+// get their LFS files too. A checkout only uses the LFS files already
+// downloaded.
+func (g *gitExec) LfsPull(repoDir string, offline bool) error {
+	if _, e1 := exec.LookPath("git-lfs"); e1 != nil {
+		return fmt.Errorf(cli.Errors.GitLfsNotInstalled)
+	}
+
+	lfsCmd := "pull"
+	if offline {
+		lfsCmd = "checkout"
+	}
+
+	if _, e2 := gitCmd(repoDir, "lfs", lfsCmd); e2 != nil {
+		return e2
+	}
+
+	_, e3 := gitCmd(repoDir, "submodule", "foreach", "--quiet", "--recursive", "git lfs "+lfsCmd)
+
+	return e3
+}
+
+func (g *gitExec) LsRemote(repoUri string) (map[string]string, error) {
+	sco, e1 := gitCmd(".", "ls-remote", repoUri)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	refs := make(map[string]string)
+
+	// Each line is "<hash>\t<ref>", annotated tags are listed again with a
+	// "^{}" suffix for the commit they point to.
+	scanner := bufio.NewScanner(bytes.NewReader(sco))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		if name := strings.TrimSuffix(fields[1], "^{}"); name != fields[1] {
+			refs[name] = fields[0]
+		} else if _, ok := refs[name]; !ok {
+			refs[name] = fields[0]
+		}
+	}
+
+	return refs, nil
+}
+
+func (g *gitExec) RemoteUrl(repoDir string) (string, error) {
+	u, e1 := gitCmd(repoDir, "config", "--get", "remote.origin.url")
+	if e1 != nil {
+		return "", e1
+	}
+
+	return strings.TrimSpace(string(u)), nil
+}
+
+func (g *gitExec) RevParse(repoDir, ref string) (string, error) {
+	hash, e1 := gitCmd(repoDir, "rev-parse", "--verify", ref+"^{commit}")
+	if e1 != nil {
+		return "", e1
+	}
+
+	return strings.Trim(string(hash), "\r\n"), nil
+}
+
+func (g *gitExec) SetRemoteUrl(repoDir, url string) error {
+	_, e1 := gitCmd(repoDir, "remote", "set-url", "origin", url)
+
+	return e1
+}
+
+func (g *gitExec) UpdateSubmodules(repoDir string, offline bool) error {
+	args := []string{"submodule", "update", "--init", "--recursive"}
+
+	if offline {
+		// Git clones a submodule that is not initialized even with --no-fetch.
+		status, e := gitCmd(repoDir, "submodule", "status", "--recursive")
+		if e != nil {
+			return e
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(status))
+		for scanner.Scan() {
+			if fields := strings.Fields(scanner.Text()); len(fields) > 1 && strings.HasPrefix(fields[0], "-") {
+				return fmt.Errorf(cli.Errors.SubmoduleNotCached, fields[1])
+			}
+		}
+
+		args = []string{"submodule", "update", "--recursive", "--no-fetch"}
+	}
+
+	sco, e1 := gitCmd(repoDir, args...)
+	if e1 != nil {
+		return e1
+	}
+
+	infof("submodule output \n%s", sco)
+
+	return nil
+}
+
+func (g *gitExec) Signed(repoDir, ref string) (bool, error) {
+	if tag := annotatedTag(repoDir, ref); tag != "" {
+		content, e1 := gitCmd(repoDir, "cat-file", "tag", tag)
+		if e1 != nil {
+			return false, e1
+		}
+		// The signature is at the end of the tag message.
+		return strings.Contains(string(content), "\n-----BEGIN "), nil
+	}
+
+	content, e2 := gitCmd(repoDir, "cat-file", "commit", "HEAD")
+	if e2 != nil {
+		return false, e2
+	}
+
+	// The signature is a header, which ends at the first blank line.
+	header, _, _ := strings.Cut(string(content), "\n\n")
+
+	return strings.Contains("\n"+header, "\ngpgsig"), nil
+}
+
+// Verify Git uses its own configuration (GPG keyring or
+// gpg.ssh.allowedSignersFile) to decide which keys are trusted.
+func (g *gitExec) Verify(repoDir, ref string) error {
+	if tag := annotatedTag(repoDir, ref); tag != "" {
+		_, e1 := gitCmd(repoDir, "verify-tag", tag)
+		return e1
+	}
+
+	_, e2 := gitCmd(repoDir, "verify-commit", "HEAD")
+
+	return e2
+}
+
+// annotatedTag Return the full name of ref when it is an annotated tag, which
+// is the only kind of tag that can be signed, otherwise an empty string.
+func annotatedTag(repoDir, ref string) string {
+	tag := "refs/tags/" + strings.TrimPrefix(ref, "refs/tags/")
+
+	kind, e := gitCmd(repoDir, "cat-file", "-t", tag)
+	if e != nil || strings.TrimSpace(string(kind)) != "tag" {
+		return ""
+	}
+
+	return tag
+}
+
+// gitCmd run a git command.
+func gitCmd(repoPath string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), gitEnv...)
+	cmd.Dir = repoPath
+	cmdStr := cmd.String()
+	infof(cli.Messages.RunningCommand, cmdStr)
+	cmdOut, cmdErr := cmd.CombinedOutput()
+	exitCode := cmd.ProcessState.ExitCode()
+
+	if cmdErr != nil {
+		return nil, fmt.Errorf(cli.Errors.RunGitFailed, args, cmdErr.Error(), cmdOut)
+	}
+
+	if exitCode != 0 {
+		return nil, fmt.Errorf(cli.Errors.GitExitErrCode, args, exitCode)
+	}
+
+	return cmdOut, nil
+}
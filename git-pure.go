@@ -0,0 +1,367 @@
+package main
+
+import (
+	"fmt"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"strings"
+)
+
+// gitPure Does all Git operations in-process, so the git binary is not needed.
+type gitPure struct{}
+
+// gitPureAuth Authentication for HTTPS remotes, set by setGitCredentials.
+var gitPureAuth transport.AuthMethod
+
+func init() {
+	// Serve local repositories in-process, the default file transport runs
+	// git-upload-pack.
+	client.InstallProtocol("file", server.DefaultServer)
+}
+
+// Bundle There is no support for git bundles in-process.
+func (g *gitPure) Bundle(repoDir, file string) error {
+	return fmt.Errorf(cli.Errors.GitBackendUnsupported, "git bundles", gitBackendGo)
+}
+
+func (g *gitPure) Checkout(repoDir, ref string) error {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return e1
+	}
+
+	wt, e2 := repo.Worktree()
+	if e2 != nil {
+		return e2
+	}
+
+	opts := &gogit.CheckoutOptions{Force: true}
+
+	// Switch to a local branch by name, like git checkout does, anything else
+	// leaves HEAD detached.
+	branch := plumbing.NewBranchReferenceName(ref)
+	if _, e := repo.Reference(branch, false); e == nil && !strings.HasPrefix(ref, "refs/") {
+		opts.Branch = branch
+	} else {
+		hash, e3 := resolveRevision(repo, repoDir, ref)
+		if e3 != nil {
+			return e3
+		}
+		opts.Hash = hash
+	}
+
+	return wt.Checkout(opts)
+}
+
+func (g *gitPure) Clone(repoUri, repoDir, branch string, depth int) error {
+	opts := &gogit.CloneOptions{
+		Auth:  gitPureAuth,
+		Depth: depth,
+		URL:   pureRepoUrl(repoUri),
+	}
+
+	if branch != "" {
+		// Like git clone --branch, the name can be a branch or a tag.
+		refName, e1 := g.remoteRefName(opts.URL, branch)
+		if e1 != nil {
+			return e1
+		}
+		opts.ReferenceName = refName
+		opts.SingleBranch = depth > 0
+	}
+
+	_, e2 := gogit.PlainClone(repoDir, false, opts)
+
+	return e2
+}
+
+func (g *gitPure) Fetch(repoDir string) error {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return e1
+	}
+
+	e2 := repo.Fetch(&gogit.FetchOptions{
+		Auth:  gitPureAuth,
+		Force: true,
+		Prune: true,
+		Tags:  gogit.AllTags,
+	})
+	if e2 != nil && e2 != gogit.NoErrAlreadyUpToDate {
+		return e2
+	}
+
+	return nil
+}
+
+func (g *gitPure) FetchCommit(repoUri, repoDir, commit string, depth int) error {
+	repo, e1 := gogit.PlainInit(repoDir, false)
+	if e1 != nil {
+		return e1
+	}
+
+	remote, e2 := repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{pureRepoUrl(repoUri)},
+	})
+	if e2 != nil {
+		return e2
+	}
+
+	e3 := remote.Fetch(&gogit.FetchOptions{
+		Auth:     gitPureAuth,
+		Depth:    depth,
+		RefSpecs: []config.RefSpec{config.RefSpec(commit + ":refs/tmpltoapp/commit")},
+		Tags:     gogit.NoTags,
+	})
+	if e3 != nil && e3 != gogit.NoErrAlreadyUpToDate {
+		return e3
+	}
+
+	return g.Checkout(repoDir, commit)
+}
+
+// LfsPull There is no Git LFS client in-process.
+func (g *gitPure) LfsPull(repoDir string, offline bool) error {
+	return fmt.Errorf(cli.Errors.GitBackendUnsupported, "Git LFS", gitBackendGo)
+}
+
+func (g *gitPure) LsRemote(repoUri string) (map[string]string, error) {
+	var list []*plumbing.Reference
+	var e1 error
+
+	repo, e2 := gogit.PlainOpen(repoUri)
+	if isRemoteRepo(repoUri) || e2 != nil {
+		list, e1 = g.lsRemote(pureRepoUrl(repoUri))
+	} else {
+		// The in-process server does not advertise the commits of annotated
+		// tags, so read the refs of a local repository directly.
+		list, e1 = localRefs(repo)
+	}
+
+	if e1 != nil {
+		return nil, e1
+	}
+
+	refs := make(map[string]string)
+
+	for _, ref := range list {
+		name := ref.Name().String()
+		hash := ref.Hash().String()
+
+		if ref.Type() == plumbing.SymbolicReference {
+			// HEAD is advertised as a symbolic ref, resolve it from the list.
+			for _, target := range list {
+				if target.Name() == ref.Target() {
+					hash = target.Hash().String()
+				}
+			}
+		}
+
+		// Prefer the commit of an annotated tag over the tag object.
+		if peeled := strings.TrimSuffix(name, "^{}"); peeled != name {
+			refs[peeled] = hash
+		} else if _, ok := refs[name]; !ok {
+			refs[name] = hash
+		}
+	}
+
+	return refs, nil
+}
+
+func (g *gitPure) RemoteUrl(repoDir string) (string, error) {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return "", e1
+	}
+
+	remote, e2 := repo.Remote("origin")
+	if e2 != nil {
+		return "", e2
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) < 1 {
+		return "", fmt.Errorf(cli.Errors.GitRemoteNoUrl, "origin", repoDir)
+	}
+
+	return urls[0], nil
+}
+
+func (g *gitPure) RevParse(repoDir, ref string) (string, error) {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return "", e1
+	}
+
+	hash, e2 := resolveRevision(repo, repoDir, ref)
+	if e2 != nil {
+		return "", e2
+	}
+
+	return hash.String(), nil
+}
+
+func (g *gitPure) SetRemoteUrl(repoDir, url string) error {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return e1
+	}
+
+	if e := repo.DeleteRemote("origin"); e != nil && e != gogit.ErrRemoteNotFound {
+		return e
+	}
+
+	_, e2 := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{url}})
+
+	return e2
+}
+
+func (g *gitPure) UpdateSubmodules(repoDir string, offline bool) error {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return e1
+	}
+
+	wt, e2 := repo.Worktree()
+	if e2 != nil {
+		return e2
+	}
+
+	subs, e3 := wt.Submodules()
+	if e3 != nil {
+		return e3
+	}
+
+	if offline {
+		for _, sub := range subs {
+			if s, e := sub.Status(); e != nil || s.Current.IsZero() {
+				return fmt.Errorf(cli.Errors.SubmoduleNotCached, sub.Config().Path)
+			}
+		}
+	}
+
+	return subs.Update(&gogit.SubmoduleUpdateOptions{
+		Auth:              gitPureAuth,
+		Init:              !offline,
+		NoFetch:           offline,
+		RecurseSubmodules: gogit.DefaultSubmoduleRecursionDepth,
+	})
+}
+
+func (g *gitPure) Signed(repoDir, ref string) (bool, error) {
+	repo, e1 := gogit.PlainOpen(repoDir)
+	if e1 != nil {
+		return false, e1
+	}
+
+	if r, e := repo.Tag(strings.TrimPrefix(ref, "refs/tags/")); e == nil {
+		if tag, e := repo.TagObject(r.Hash()); e == nil {
+			return tag.PGPSignature != "", nil
+		}
+	}
+
+	head, e2 := repo.Head()
+	if e2 != nil {
+		return false, e2
+	}
+
+	commit, e3 := repo.CommitObject(head.Hash())
+	if e3 != nil {
+		return false, e3
+	}
+
+	return commit.PGPSignature != "", nil
+}
+
+// errCannotVerify The backend has no keyring to verify signatures with.
+var errCannotVerify = fmt.Errorf(cli.Errors.GitBackendUnsupported, "signature verification", gitBackendGo)
+
+// Verify There is no keyring to verify signatures with in-process.
+func (g *gitPure) Verify(repoDir, ref string) error {
+	return errCannotVerify
+}
+
+// lsRemote List the references of a repository without cloning it.
+func (g *gitPure) lsRemote(repoUrl string) ([]*plumbing.Reference, error) {
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repoUrl},
+	})
+
+	return remote.List(&gogit.ListOptions{Auth: gitPureAuth, PeelingOption: gogit.AppendPeeled})
+}
+
+// remoteRefName Find the full name of a branch or tag on a remote.
+func (g *gitPure) remoteRefName(repoUrl, name string) (plumbing.ReferenceName, error) {
+	if strings.HasPrefix(name, "refs/") {
+		return plumbing.ReferenceName(name), nil
+	}
+
+	refs, e1 := g.lsRemote(repoUrl)
+	if e1 != nil {
+		return "", e1
+	}
+
+	for _, want := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(name), plumbing.NewTagReferenceName(name)} {
+		for _, ref := range refs {
+			if ref.Name() == want {
+				return want, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf(cli.Errors.GitRefNotFound, name, cli.Redact(repoUrl))
+}
+
+// localRefs List the refs of a local repository, with the commits of
+// annotated tags appended like a server does.
+func localRefs(repo *gogit.Repository) ([]*plumbing.Reference, error) {
+	iter, e1 := repo.References()
+	if e1 != nil {
+		return nil, e1
+	}
+
+	var list []*plumbing.Reference
+	e2 := iter.ForEach(func(ref *plumbing.Reference) error {
+		list = append(list, ref)
+		if tag, e := repo.TagObject(ref.Hash()); e == nil && ref.Name().IsTag() {
+			list = append(list, plumbing.NewHashReference(ref.Name()+"^{}", tag.Target))
+		}
+		return nil
+	})
+
+	return list, e2
+}
+
+// pureRepoUrl The in-process server needs the Git directory of a local
+// repository with a work tree.
+func pureRepoUrl(repoUri string) string {
+	if !isRemoteRepo(repoUri) && stdlib.DirExist(repoUri+cli.PS+gitConfDir) {
+		return repoUri + cli.PS + gitConfDir
+	}
+
+	return repoUri
+}
+
+// resolveRevision Resolve a ref to a commit, falling back to the remote
+// branch of the same name, like git checkout does.
+func resolveRevision(repo *gogit.Repository, repoDir, ref string) (plumbing.Hash, error) {
+	hash, e1 := repo.ResolveRevision(plumbing.Revision(ref))
+	if e1 == nil {
+		return *hash, nil
+	}
+
+	if hash, e := repo.ResolveRevision(plumbing.Revision("origin/" + ref)); e == nil {
+		return *hash, nil
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf(cli.Errors.GitRefNotFound, ref, repoDir)
+}
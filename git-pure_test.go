@@ -0,0 +1,188 @@
+package main
+
+import (
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"strings"
+	"testing"
+)
+
+// usePureGit Run a test with the in-process backend.
+func usePureGit(t *testing.T) {
+	prev := git
+	git = &gitPure{}
+	t.Cleanup(func() { git = prev })
+}
+
+func TestPureGitClone(tester *testing.T) {
+	var testCases = []struct {
+		name     string
+		repo     string
+		outPath  string
+		branch   string
+		wantHash string
+	}{
+		{"fullRef", "repo-01.git", TmpDir + cli.PS + "pure-repo-01-refs-heads-main", "refs/heads/main", "b7e42844c597d2beaf774eddfdcb653a2a4b0050"},
+		{"tag", "repo-02", TmpDir + cli.PS + "pure-repo-02-0.1.0", "0.1.0", "ec2e5bae82f4d955d0758223699867ab851daeba"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			usePureGit(t)
+			repoPath := test.SetupARepository(tc.repo, TmpDir, FixtureDir, cli.PS)
+
+			gotPath, gotHash, err := gitClone(repoPath, tc.outPath, tc.branch)
+
+			if err != nil {
+				t.Errorf("got an unexpected err: %s", err)
+			}
+
+			if gotHash != tc.wantHash {
+				t.Errorf("got %v, want %v", gotHash, tc.wantHash)
+			}
+
+			if gotPath != tc.outPath {
+				t.Errorf("got %v, want %v", gotPath, tc.outPath)
+			}
+		})
+	}
+}
+
+func TestPureGitCannotClone(tester *testing.T) {
+	usePureGit(tester)
+
+	_, _, err := gitClone(TmpDir+cli.PS+"does-not-exist.git", TmpDir+cli.PS+"pure-dne", "main")
+
+	if err == nil {
+		tester.Error("did not get expected err")
+	}
+}
+
+func TestPureGitCheckout(tester *testing.T) {
+	usePureGit(tester)
+
+	// The origin of a cached repo is a repo, not a bundle, so it can be fetched.
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+	cacheDir := TmpDir + cli.PS + "pure-repo-02-cache"
+	if _, _, e := gitClone(repoPath, cacheDir, "main"); e != nil {
+		tester.Fatal(e)
+	}
+
+	_, gotHash, gotErr := gitCheckout(cacheDir, "refs/tags/0.1.0")
+
+	if gotErr != nil {
+		tester.Errorf("unexpected error in test %q", gotErr.Error())
+	}
+
+	if want := "ec2e5bae82f4d955d0758223699867ab851daeba"; gotHash != want {
+		tester.Errorf("got %v, want %v", gotHash, want)
+	}
+}
+
+func TestPureGetRemoteTags(tester *testing.T) {
+	var testCases = []struct {
+		name      string
+		bundle    string
+		want      string
+		shouldErr bool
+	}{
+		{"hasTags", "repo-04", "1.0.0,0.2.0,0.1.1,0.1.0", false},
+		{"noTags", "repo-05", "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			usePureGit(t)
+			repoPath := test.SetupARepository(tc.bundle, TmpDir, FixtureDir, cli.PS)
+
+			got, gotErr := getRemoteTags(repoPath)
+
+			if tc.shouldErr != (gotErr != nil) {
+				t.Errorf("got error %v, want an error %v", gotErr, tc.shouldErr)
+			}
+
+			if t1 := strings.Join(got, ","); t1 != tc.want {
+				t.Errorf("got %v, want %v", t1, tc.want)
+			}
+		})
+	}
+}
+
+func TestPureGitVerify(tester *testing.T) {
+	usePureGit(tester)
+	repoPath := test.SetupARepository("repo-04", TmpDir, FixtureDir, cli.PS)
+
+	if gotErr := gitVerify(repoPath, "refs/tags/1.0.0", true); gotErr == nil {
+		tester.Errorf("want an error, the tag is not signed")
+	}
+
+	if gotErr := gitVerify(repoPath, "refs/tags/1.0.0", false); gotErr != nil {
+		tester.Errorf("got %v, want nil for an unsigned tag", gotErr)
+	}
+}
+
+func TestPureGitVerifySigned(tester *testing.T) {
+	repoPath := makeSignedRepo(tester, "pure-signed-01", true)
+	usePureGit(tester)
+
+	signed, e := git.Signed(repoPath, "refs/tags/1.0.0")
+	if e != nil || !signed {
+		tester.Fatalf("got %v, %v; want the tag signed", signed, e)
+	}
+
+	// A signature that cannot be checked in-process only fails when required.
+	if gotErr := gitVerify(repoPath, "refs/tags/1.0.0", false); gotErr != nil {
+		tester.Errorf("got %v, want nil", gotErr)
+	}
+
+	if gotErr := gitVerify(repoPath, "refs/tags/1.0.0", true); gotErr == nil {
+		tester.Errorf("want an error, signatures cannot be verified in-process")
+	}
+}
+
+func TestPureGitSubmodules(tester *testing.T) {
+	usePureGit(tester)
+	repoPath := makeSuperRepo(tester, "pure-super-01")
+	outPath := TmpDir + cli.PS + "pure-super-01-clone"
+
+	if _, _, e := gitClone(repoPath, outPath, "main"); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if e := gitSubmodules(outPath, false); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !stdlib.PathExist(outPath + cli.PS + "shared" + cli.PS + "shared.txt") {
+		tester.Error("submodule was not cloned")
+	}
+}
+
+func TestPureGitSubmodulesOffline(tester *testing.T) {
+	usePureGit(tester)
+	testSubmodulesOffline(tester, "pure-super-02")
+}
+
+func TestPureGitLfsPull(tester *testing.T) {
+	usePureGit(tester)
+
+	if e := git.LfsPull(TmpDir, false); e == nil {
+		tester.Error("did not get expected err")
+	}
+}
+
+func TestPureGitRemoteUrlNone(tester *testing.T) {
+	usePureGit(tester)
+	repoPath := TmpDir + cli.PS + "pure-no-remote-url"
+	if _, e := gitCmd(TmpDir, "init", "-q", "pure-no-remote-url"); e != nil {
+		tester.Fatal(e)
+	}
+	if _, e := gitCmd(repoPath, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"); e != nil {
+		tester.Fatal(e)
+	}
+
+	if _, e := git.RemoteUrl(repoPath); e == nil {
+		tester.Error("did not get expected err")
+	}
+}
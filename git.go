@@ -1,84 +1,188 @@
 package main
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/kohirens/stdlib"
 	"github.com/kohirens/tmpltoapp/internal/cli"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
-// gitClone Clone a repo from a path/URL to a local directory.
+// gitCredentialHelper A git credential helper that answers with the
+// credentials passed in the environment of the git command.
+const gitCredentialHelper = `!f() { test "$1" = get && echo "username=${TMPLTOAPP_GIT_USERNAME}" && echo "password=${TMPLTOAPP_GIT_PASSWORD}"; }; f`
+
+// gitEnv Extra environment for git commands, this keeps credentials off the
+// command line and out of the logs.
+var gitEnv []string
+
+// gitBackend Git operations needed to get a template. The exec backend runs
+// the git binary, the pure backend does them in-process.
+type gitBackend interface {
+	// Bundle Pack all refs of a local repository into a bundle file.
+	Bundle(repoDir, file string) error
+	// Checkout a branch, tag or commit in a local repository.
+	Checkout(repoDir, ref string) error
+	// Clone a repository, only the last depth commits of branch when depth > 0.
+	Clone(repoUri, repoDir, branch string, depth int) error
+	// Fetch all branches and tags from the origin of a local repository.
+	Fetch(repoDir string) error
+	// FetchCommit Make a new repository with a single commit of a remote
+	// checked out, fails when the server does not allow fetching by hash.
+	FetchCommit(repoUri, repoDir, commit string, depth int) error
+	// LfsPull Replace Git LFS pointer files with their content, only with
+	// content already downloaded when offline.
+	LfsPull(repoDir string, offline bool) error
+	// LsRemote Map the refs of a repository to the commits they point to,
+	// without cloning it.
+	LsRemote(repoUri string) (map[string]string, error)
+	// RemoteUrl Return the URL of the origin of a local repository.
+	RemoteUrl(repoDir string) (string, error)
+	// RevParse Resolve a ref to a commit hash.
+	RevParse(repoDir, ref string) (string, error)
+	// SetRemoteUrl Change the URL of the origin of a local repository.
+	SetRemoteUrl(repoDir, url string) error
+	// UpdateSubmodules Clone and checkout the submodules of a local
+	// repository, recursively. When offline, submodules are only checked out
+	// from what was cloned before, and one that was not is an error.
+	UpdateSubmodules(repoDir string, offline bool) error
+	// Signed Check if a tag, or the HEAD commit for any other ref, is signed.
+	Signed(repoDir, ref string) (bool, error)
+	// Verify the signature of a tag, or of the HEAD commit for any other ref.
+	Verify(repoDir, ref string) error
+}
+
+const (
+	gitBackendAuto = "auto"
+	gitBackendExec = "exec"
+	gitBackendGo   = "go"
+)
+
+// git Backend for all Git operations.
+var git gitBackend = &gitExec{}
+
+// newGitBackend Make the backend selected by the GitBackend setting. Auto
+// (or no setting) uses the git binary when it is installed.
+func newGitBackend(name string) (gitBackend, error) {
+	switch name {
+	case gitBackendExec:
+		return &gitExec{}, nil
+	case gitBackendGo:
+		return &gitPure{}, nil
+	case gitBackendAuto, "":
+		if _, e := exec.LookPath("git"); e != nil {
+			infof(cli.Messages.GitNotInstalled)
+			return &gitPure{}, nil
+		}
+		return &gitExec{}, nil
+	}
+
+	return nil, fmt.Errorf(cli.Errors.BadGitBackend, name)
+}
+
+// setupGit Select the git backend and the credentials for a template.
+func setupGit(cfg *cli.Config) error {
+	backend, e1 := newGitBackend(cfg.UsrOpts.GitBackend)
+	if e1 != nil {
+		return e1
+	}
+	git = backend
+
+	setGitCredentials(cfg.TmplPath, cfg.UsrOpts.Credentials)
+
+	return setGitSsh(cfg.TmplPath, cfg.UsrOpts.SshCommand, cfg.UsrOpts.SshKey)
+}
+
+// gitRef A ref of a repository resolved to a commit.
+type gitRef struct {
+	Name   string // Full name of the ref, empty when a commit hash was given.
+	Commit string // Commit hash, abbreviated when it could not be resolved without a clone.
+}
+
+// gitClone Clone a repo from a path/URL to a local directory and checkout a
+// ref, which can be a branch, tag, full ref or a full or abbreviated commit
+// hash. Returns the commit that was checked out.
 func gitClone(repoUri, repoDir, refName string) (string, string, error) {
-	infof("branch to clone is %q", refName)
+	infof("ref to clone is %q", refName)
 	infof("git clone %s", repoUri)
 
-	var sco []byte
-	var e1 error
+	ref, e1 := resolveRef(repoUri, refName)
+	if e1 != nil {
+		return "", "", e1
+	}
 
-	if isRemoteRepo(repoUri) {
-		branchName := refName
-		re := regexp.MustCompile("^refs/[^/]+/(.*)$")
-		if re.MatchString(refName) {
-			branchName = re.ReplaceAllString(refName, "${1}")
-		}
+	infof(cli.Messages.GitResolvedRef, refName, ref.Commit)
 
-		logf("cloning branch name: %v", branchName)
+	isRemote := isRemoteRepo(repoUri)
+	shortName := regexp.MustCompile("^refs/(heads|tags)/").ReplaceAllString(ref.Name, "")
 
-		// git clone --depth 1 --branch <tag_name> <repo_url>
+	switch {
+	case isRemote && shortName != ref.Name:
+		logf("cloning branch name: %v", shortName)
 		// NOTE: Branch cannot be a full ref but can be short ref name or a tag.
-		sco, e1 = gitCmd(".", "clone", "--depth", "1", "--branch", branchName, repoUri, repoDir)
-	} else {
-		// git clone <repo_url>
-		sco, e1 = gitCmd(".", "clone", repoUri, repoDir)
-		if e1 != nil {
-			return "", "", fmt.Errorf(cli.Errors.Cloning, repoUri, e1.Error())
+		if e := git.Clone(repoUri, repoDir, shortName, 1); e != nil {
+			return "", "", fmt.Errorf(cli.Errors.Cloning, repoUri, e.Error())
 		}
-
-		infof("clone output \n%s", sco)
-
-		// get current branch
-		cb, e4 := gitCmd(repoDir, "branch", "--show-current", refName)
-		if e4 != nil {
-			return "", "", fmt.Errorf(cli.Errors.CurrentBranch, repoUri, e4.Error(), cb)
+	case isRemote && isFullHash(ref.Commit) && git.FetchCommit(repoUri, repoDir, ref.Commit, 1) == nil:
+		logf("fetched commit: %v", ref.Commit)
+	default:
+		// A local repo, a commit the server will not send by itself, or an
+		// abbreviated hash; clone it all and checkout the commit.
+		_ = os.RemoveAll(repoDir)
+		if e := git.Clone(repoUri, repoDir, "", 0); e != nil {
+			return "", "", fmt.Errorf(cli.Errors.Cloning, repoUri, e.Error())
 		}
-		// skip if already on desired branch
-		if strings.Trim(bytes.NewBuffer(cb).String(), "\r\n") != refName {
-			// git checkout <ref_name>
-			co, e3 := gitCmd(repoDir, "checkout", "-b", refName)
-			if e3 != nil {
-				return "", "", fmt.Errorf(cli.Errors.Checkout, repoUri, e3.Error(), co)
-			}
 
-			infof("checkout output \n%s", co)
+		if e := git.Checkout(repoDir, ref.Commit); e != nil {
+			return "", "", fmt.Errorf(cli.Errors.GitCheckoutFailed, e.Error())
 		}
 	}
 
 	latestCommitHash, e2 := getLastCommitHash(repoDir)
 	if e2 != nil {
-		return "", "", fmt.Errorf(cli.Errors.GettingCommitHash, repoDir, e2.Error())
+		return "", "", e2
+	}
+
+	if !strings.HasPrefix(latestCommitHash, ref.Commit) {
+		return "", "", fmt.Errorf(cli.Errors.GitWrongCommit, repoDir, latestCommitHash, ref.Commit)
 	}
 
 	return repoDir, latestCommitHash, nil
 }
 
-// gitCheckout Open an existing repo and checkout commit by full ref-name
+// gitCheckout Open an existing repo, fetch the latest, and checkout a ref.
 func gitCheckout(repoLocalPath, ref string) (string, string, error) {
 	infof("pulling latest\n")
-	_, e1 := gitCmd(repoLocalPath, "fetch", "--all", "-p")
-	if e1 != nil {
+	if e1 := git.Fetch(repoLocalPath); e1 != nil {
 		return "", "", fmt.Errorf(cli.Errors.GitFetchFailed, repoLocalPath, ref, e1.Error())
 	}
 
+	return gitCheckoutCached(repoLocalPath, ref)
+}
+
+// gitCheckoutCached Checkout a ref of a local repository without fetching,
+// the ref must already be in the repository.
+func gitCheckoutCached(repoLocalPath, ref string) (string, string, error) {
 	infof(cli.Messages.RefInfo, ref)
-	infof(cli.Messages.GitCheckout, ref)
 
-	_, e2 := gitCmd(repoLocalPath, "checkout", ""+ref)
+	commit, e2 := resolveLocalRef(repoLocalPath, ref)
 	if e2 != nil {
-		return "", "", fmt.Errorf(cli.Errors.GitCheckoutFailed, e2.Error())
+		return "", "", e2
+	}
+
+	infof(cli.Messages.GitCheckout, commit)
+
+	if e3 := git.Checkout(repoLocalPath, commit); e3 != nil {
+		return "", "", fmt.Errorf(cli.Errors.GitCheckoutFailed, e3.Error())
 	}
 
 	repoDir, e8 := filepath.Abs(repoLocalPath)
@@ -94,102 +198,354 @@ func gitCheckout(repoLocalPath, ref string) (string, string, error) {
 	return repoDir, latestCommitHash, nil
 }
 
-// gitCmd run a git command.
-func gitCmd(repoPath string, args ...string) ([]byte, error) {
-	cmd := exec.Command("git", args...)
-	cmd.Env = os.Environ()
-	cmd.Dir = repoPath
-	cmdStr := cmd.String()
-	infof(cli.Messages.RunningCommand, cmdStr)
-	cmdOut, cmdErr := cmd.CombinedOutput()
-	exitCode := cmd.ProcessState.ExitCode()
+// resolveRef Find the commit a ref points to on a repository without cloning
+// it. Branches are looked for before tags, like git does. An abbreviated
+// commit hash is returned as-is, it can only be resolved after a clone.
+func resolveRef(repoUri, refName string) (*gitRef, error) {
+	if refName == "" {
+		refName = "HEAD"
+	}
+
+	if isFullHash(refName) {
+		return &gitRef{Commit: strings.ToLower(refName)}, nil
+	}
 
-	if cmdErr != nil {
-		return nil, fmt.Errorf(cli.Errors.RunGitFailed, args, cmdErr.Error(), cmdOut)
+	refs, e1 := git.LsRemote(repoUri)
+	if e1 != nil {
+		return nil, fmt.Errorf(cli.Errors.GitLsRemote, repoUri, e1.Error())
+	}
+
+	for _, name := range []string{refName, "refs/heads/" + refName, "refs/tags/" + refName} {
+		if commit, ok := refs[name]; ok {
+			return &gitRef{Name: name, Commit: commit}, nil
+		}
 	}
 
-	if exitCode != 0 {
-		return nil, fmt.Errorf(cli.Errors.GitExitErrCode, args, exitCode)
+	if regexp.MustCompile("^[a-fA-F0-9]{4,39}$").MatchString(refName) {
+		return &gitRef{Commit: strings.ToLower(refName)}, nil
 	}
 
-	return cmdOut, nil
+	return nil, fmt.Errorf(cli.Errors.GitRefNotFound, refName, repoUri)
+}
+
+// resolveLocalRef Find the commit a ref points to in a local clone, where
+// branches are the ones just fetched from its origin.
+func resolveLocalRef(repoDir, refName string) (string, error) {
+	candidates := []string{refName}
+	if !isFullHash(refName) {
+		branch := strings.TrimPrefix(refName, "refs/heads/")
+		candidates = []string{"refs/remotes/origin/" + branch, "refs/tags/" + refName, refName}
+	}
+
+	for _, name := range candidates {
+		if commit, e := git.RevParse(repoDir, name); e == nil {
+			return commit, nil
+		}
+	}
+
+	return "", fmt.Errorf(cli.Errors.GitRefNotFound, refName, repoDir)
+}
+
+// isFullHash Check if a ref is a full SHA-1 or SHA-256 commit hash.
+func isFullHash(ref string) bool {
+	return regexp.MustCompile("^([a-fA-F0-9]{40}|[a-fA-F0-9]{64})$").MatchString(ref)
 }
 
 // getLastCommitHash Returns the HEAD commit hash.
 func getLastCommitHash(repoDir string) (string, error) {
-	latestCommitHash, e1 := gitCmd(repoDir, "rev-parse", "HEAD")
+	latestCommitHash, e1 := git.RevParse(repoDir, "HEAD")
 	if e1 != nil {
 		return "", fmt.Errorf(cli.Errors.GettingCommitHash, repoDir, e1.Error())
 	}
 
-	return strings.Trim(string(latestCommitHash), "\n"), nil
+	return latestCommitHash, nil
 }
 
-// getLatestTag Will return the latest tag or an empty string from a repository.
-func getLatestTag(repoDir string) (string, error) {
+// getVersionTag Return the tag of the highest version of a repository that
+// satisfies a constraint, such as "^2.1". Pre-releases are only considered
+// when pre is true.
+func getVersionTag(repoDir, constraint string, pre bool) (string, error) {
 	tags, e1 := getRemoteTags(repoDir)
 	if e1 != nil {
 		return "", fmt.Errorf(cli.Errors.GetLatestTag, repoDir, e1.Error())
 	}
 
-	return tags[0], nil
+	tag, e2 := cli.PickVersion(tags, constraint, pre)
+	if e2 != nil {
+		return "", fmt.Errorf(cli.Errors.GetLatestTag, repoDir, e2.Error())
+	}
+
+	return tag, nil
 }
 
-// getRemoteTags Get the remote tags on a repo using git ls-remote.
+// getRemoteTags Get the tags on a repo without cloning it, the highest
+// version first.
 func getRemoteTags(repo string) ([]string, error) {
-	// Even without cloning or fetching, you can check the list of tags on the upstream repo with git ls-remote:
-	sco, e1 := gitCmd(repo, "ls-remote", "--sort=-version:refname", "--tags")
+	refs, e1 := git.LsRemote(repo)
 	if e1 != nil {
 		return nil, fmt.Errorf(cli.Errors.GetRemoteTags, e1.Error())
 	}
 
-	reTags := regexp.MustCompile("[a-f0-9]+\\s+refs/tags/(\\S+)")
-	mat := reTags.FindAllSubmatch(sco, -1)
-	if mat == nil {
+	var tags []string
+	for name := range refs {
+		if strings.HasPrefix(name, "refs/tags/") {
+			tags = append(tags, strings.TrimPrefix(name, "refs/tags/"))
+		}
+	}
+
+	if len(tags) < 1 {
 		return nil, fmt.Errorf("%s", "no tags found")
 	}
 
-	ret := make([]string, len(mat))
-	for i, v := range mat {
-		dbugf(cli.Messages.RemoteTagDbug1, string(v[1]))
-		ret[i] = string(v[1])
+	sort.Strings(tags)
+	sort.SliceStable(tags, func(i, j int) bool {
+		return compareVersions(tags[i], tags[j]) > 0
+	})
+
+	for _, tag := range tags {
+		dbugf(cli.Messages.RemoteTagDbug1, tag)
 	}
 
-	return ret, nil
+	return tags, nil
 }
 
-// getRepoDir Extract a local dirname from a Git URL.
+// compareVersions Compare tags the way git sorts by version:refname; runs of
+// digits are compared as numbers. Returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	reChunk := regexp.MustCompile(`\d+|\D+`)
+	ac, bc := reChunk.FindAllString(a, -1), reChunk.FindAllString(b, -1)
+
+	for i := 0; i < len(ac) && i < len(bc); i++ {
+		x, y := ac[i], bc[i]
+		xn, e1 := strconv.Atoi(x)
+		yn, e2 := strconv.Atoi(y)
+
+		switch {
+		case e1 == nil && e2 == nil && xn != yn:
+			if xn < yn {
+				return -1
+			}
+			return 1
+		case (e1 != nil || e2 != nil) && x != y:
+			return strings.Compare(x, y)
+		}
+	}
+
+	switch {
+	case len(ac) < len(bc):
+		return -1
+	case len(ac) > len(bc):
+		return 1
+	}
+
+	return 0
+}
+
+// getRepoDir Name of the cache directory for a Git template at a ref.
 func getRepoDir(repoLocation, refName string) string {
 	if len(repoLocation) < 1 {
 		return repoLocation
 	}
 
-	baseName := filepath.Base(repoLocation)
+	dirName := cli.CacheKey(repoLocation, refName)
+
+	infof(cli.Messages.RepoDir, dirName)
+
+	return dirName
+}
+
+// legacyRepoDir Name of the cache directory for a Git template that older
+// versions used, the base name of the template and the ref.
+func legacyRepoDir(repoLocation, refName string) string {
+	location := repoLocation
+	if cli.IsScpLike(location) {
+		location = cli.ScpPath(location)
+	}
 
-	// trim .git from the end
-	baseName = strings.TrimRight(baseName, ".git")
+	baseName := strings.TrimRight(filepath.Base(location), ".git")
 
-	// append ref, branch, or tag
 	if refName != "" {
 		baseName = baseName + "-" + strings.ReplaceAll(refName, "/", "-")
 	}
 
-	infof(cli.Messages.RepoDir, baseName)
-
 	return baseName
 }
 
-// isRemoteRepo return true if Git repository is a remote URL or false if local.
-func isRemoteRepo(repoLocation string) bool {
-	if len(repoLocation) < 1 {
-		return false
+// migrateRepoDir Move a clone made by an older version to its cache
+// directory. Different templates could have the same old directory, so it is
+// only moved when its origin is the template.
+func migrateRepoDir(cacheDir, repoUri, refName, repoDir string) {
+	legacy := cacheDir + cli.PS + legacyRepoDir(repoUri, refName)
+	if legacy == repoDir || stdlib.DirExist(repoDir) || !stdlib.DirExist(legacy+cli.PS+gitConfDir) {
+		return
+	}
+
+	origin, e1 := git.RemoteUrl(legacy)
+	if e1 != nil || !cli.SameSource(origin, repoUri) {
+		return
+	}
+
+	if e := os.Rename(legacy, repoDir); e != nil {
+		dbugf(e.Error())
+		return
+	}
+
+	infof(cli.Messages.CacheMigrated, legacy, repoDir)
+}
+
+// gitVerify Verify the signature of a tag, or of the HEAD commit for any
+// other ref, when it is signed. A ref that is not signed, or that the backend
+// cannot verify, only fails when a signature is required.
+func gitVerify(repoDir, refName string, required bool) error {
+	signed, e1 := git.Signed(repoDir, refName)
+	if e1 != nil {
+		return fmt.Errorf(cli.Errors.GitVerifyFailed, refName, e1.Error())
+	}
+
+	if !signed {
+		if required {
+			return fmt.Errorf(cli.Errors.SignatureRequired, refName)
+		}
+		infof(cli.Messages.GitUnsigned, refName)
+		return nil
+	}
+
+	infof(cli.Messages.GitVerify, refName)
+
+	e2 := git.Verify(repoDir, refName)
+	if errors.Is(e2, errCannotVerify) && !required {
+		logf(cli.Messages.GitVerifySkipped, refName, e2.Error())
+		return nil
 	}
-	// git@github.com:kohirens/tmpltoap.git
-	// https://github.com/kohirens/tmpltoapp.git
-	isGitUri := regexp.MustCompile("^(git|http|https)://.+$")
-	if isGitUri.MatchString(repoLocation) {
-		return true
+	if e2 != nil {
+		return fmt.Errorf(cli.Errors.GitVerifyFailed, refName, e2.Error())
 	}
 
-	return false
+	return nil
+}
+
+// gitSubmodules Get the submodules of a local repository, recursively,
+// without going to the network when offline.
+func gitSubmodules(repoDir string, offline bool) error {
+	infof(cli.Messages.GitSubmodules, repoDir)
+
+	if e := git.UpdateSubmodules(repoDir, offline); e != nil {
+		return fmt.Errorf(cli.Errors.GitSubmodules, repoDir, e.Error())
+	}
+
+	return nil
+}
+
+// gitLfsPull Get the content of the Git LFS files in a local repository, when
+// it has any. When that is not possible the pointer files are left as they
+// are, for the template check to report.
+func gitLfsPull(repoDir string, offline bool) {
+	pointers, e1 := cli.FindLfsPointers(repoDir)
+	if e1 != nil || len(pointers) == 0 {
+		return
+	}
+
+	infof(cli.Messages.GitLfsPull, repoDir)
+
+	if e := git.LfsPull(repoDir, offline); e != nil {
+		logf(cli.Messages.GitLfsSkipped, e.Error())
+	}
+}
+
+// setGitCredentials Have git authenticate to an HTTPS remote with the
+// credentials configured for its host. Git reads them from its environment
+// through a credential helper and GIT_CONFIG_* variables.
+func setGitCredentials(repoUri string, credentials map[string]*cli.Credential) {
+	gitEnv = nil
+	gitPureAuth = nil
+
+	u, e1 := url.Parse(repoUri)
+	// Credentials are only sent encrypted.
+	if e1 != nil || u.Scheme != "https" {
+		return
+	}
+
+	cred := cli.LookupCredential(u.Hostname(), credentials)
+	if cred == nil {
+		return
+	}
+
+	var gitConfig [][2]string
+
+	username, password := cred.Username, cred.Password
+	if cred.Token != "" {
+		password = cred.Token
+		if username == "" {
+			username = "x-access-token"
+		}
+	}
+
+	if password != "" {
+		gitConfig = append(gitConfig, [2]string{"credential.helper", ""}, [2]string{"credential.helper", gitCredentialHelper})
+		gitEnv = append(gitEnv, "TMPLTOAPP_GIT_USERNAME="+username, "TMPLTOAPP_GIT_PASSWORD="+password)
+		gitPureAuth = &githttp.BasicAuth{Username: username, Password: password}
+	}
+
+	headers := make([]string, 0, len(cred.Headers))
+	for k := range cred.Headers {
+		headers = append(headers, k)
+	}
+	sort.Strings(headers)
+	for _, k := range headers {
+		gitConfig = append(gitConfig, [2]string{"http.extraHeader", k + ": " + cred.Headers[k]})
+	}
+
+	gitEnv = append(gitEnv, "GIT_TERMINAL_PROMPT=0", fmt.Sprintf("GIT_CONFIG_COUNT=%d", len(gitConfig)))
+	for i, kv := range gitConfig {
+		gitEnv = append(gitEnv, fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i, kv[0]), fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i, kv[1]))
+	}
+
+	infof(cli.Messages.GitCredentials, u.Hostname())
+}
+
+// setGitSsh Have git use an SSH command, or key, from the config to reach an
+// SSH remote. Without either, git uses its own configuration and any
+// GIT_SSH_COMMAND in the environment.
+func setGitSsh(repoUri, sshCommand, sshKey string) error {
+	if !cli.IsSshLocation(repoUri) || (sshCommand == "" && sshKey == "") {
+		return nil
+	}
+
+	if sshCommand == "" {
+		sshCommand = "ssh -i " + shellQuote(sshKey) + " -o IdentitiesOnly=yes"
+	}
+
+	gitEnv = append(gitEnv, "GIT_SSH_COMMAND="+sshCommand)
+	infof(cli.Messages.GitSsh, sshCommand)
+
+	// The pure backend does not run a command, it can only use the key.
+	if _, isPure := git.(*gitPure); isPure && sshKey != "" {
+		user := "git"
+		if u, e := url.Parse(repoUri); e == nil && u.User != nil {
+			user = u.User.Username()
+		} else if i := strings.Index(repoUri, "@"); i > 0 && cli.IsScpLike(repoUri) {
+			user = repoUri[:i]
+		}
+
+		auth, e1 := gitssh.NewPublicKeysFromFile(user, sshKey, "")
+		if e1 != nil {
+			return fmt.Errorf(cli.Errors.SshKey, sshKey, e1.Error())
+		}
+		gitPureAuth = auth
+	}
+
+	return nil
+}
+
+// shellQuote Quote a value for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// isRemoteRepo return true if Git repository is a remote URL or false if local.
+// For example git@github.com:kohirens/tmpltoapp.git,
+// ssh://git@github.com/kohirens/tmpltoapp.git or
+// https://github.com/kohirens/tmpltoapp.git.
+func isRemoteRepo(repoLocation string) bool {
+	return cli.IsRemoteLocation(repoLocation)
 }
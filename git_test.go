@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"github.com/kohirens/stdlib"
 	"github.com/kohirens/tmpltoapp/internal/cli"
 	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -100,12 +104,17 @@ func TestGetRepoDir(tester *testing.T) {
 		refName string
 		want    string
 	}{
-		{"local", "/repo-01", "", "repo-01"},
-		{"url", "https://example.com/repo-01.git", "", "repo-01"},
-		{"bareRepo", "/repo-01.git", "", "repo-01"},
-		{"bareRepo", "/repo-02.git", "refs/heads/main", "repo-02-refs-heads-main"},
-		{"bareRepo", "/repo-02.git", "refs/tags/0.1.0", "repo-02-refs-tags-0.1.0"},
-		{"bareRepo", "/repo-02.git", "refs/remotes/origin/HEAD", "repo-02-refs-remotes-origin-HEAD"},
+		{"local", "/repo-01", "", "repo-01-9aff2fb02e9621f8"},
+		{"url", "https://example.com/repo-01.git", "", "repo-01-7847aa210436be46"},
+		{"bareRepo", "/repo-01.git", "", "repo-01-1bbf254fb21dd627"},
+		{"fullBranchRef", "/repo-02.git", "refs/heads/main", "repo-02-refs-heads-main-368d8d6f26b14b2d"},
+		{"fullTagRef", "/repo-02.git", "refs/tags/0.1.0", "repo-02-refs-tags-0.1.0-ed5221bf94aaf69d"},
+		{"fullRemoteRef", "/repo-02.git", "refs/remotes/origin/HEAD", "repo-02-refs-remotes-origin-HEAD-ff6f1534ee4e9153"},
+		{"ssh", "ssh://git@example.com/org/repo-03.git", "main", "repo-03-main-f31d740e91684f14"},
+		{"scpLike", "git@example.com:org/repo-03.git", "main", "repo-03-main-f31d740e91684f14"},
+		{"sameBaseName", "https://github.com/a/web", "main", "web-main-b83067e986b7299e"},
+		{"sameBaseName2", "https://github.com/b/web", "main", "web-main-47c804433fb370db"},
+		{"endsInGit", "https://github.com/org/digit.git", "main", "digit-main-85975df8b8e5e281"},
 	}
 
 	for _, tc := range testCases {
@@ -119,6 +128,53 @@ func TestGetRepoDir(tester *testing.T) {
 	}
 }
 
+func TestLegacyRepoDir(tester *testing.T) {
+	var testCases = []struct {
+		name    string
+		repo    string
+		refName string
+		want    string
+	}{
+		{"local", "/repo-01", "", "repo-01"},
+		{"url", "https://example.com/repo-01.git", "", "repo-01"},
+		{"bareRepo", "/repo-02.git", "refs/heads/main", "repo-02-refs-heads-main"},
+		{"scpLike", "git@example.com:repo-03.git", "main", "repo-03-main"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			if got := legacyRepoDir(tc.repo, tc.refName); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// Move a clone from its old cache directory, only when it is of the template.
+func TestMigrateRepoDir(tester *testing.T) {
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+	cacheDir := TmpDir + cli.PS + "cache-migrate"
+	_ = os.MkdirAll(cacheDir, cli.DirMode)
+
+	if _, e := gitCmd(".", "clone", "--quiet", repoPath, cacheDir+cli.PS+legacyRepoDir(repoPath, "main")); e != nil {
+		tester.Fatal(e)
+	}
+
+	other := cacheDir + cli.PS + getRepoDir(TmpDir+cli.PS+"other"+cli.PS+"repo-02", "main")
+	migrateRepoDir(cacheDir, TmpDir+cli.PS+"other"+cli.PS+"repo-02", "main", other)
+
+	if stdlib.DirExist(other) {
+		tester.Error("a clone of another template was moved")
+	}
+
+	repoDir := cacheDir + cli.PS + getRepoDir(repoPath, "main")
+	migrateRepoDir(cacheDir, repoPath, "main", repoDir)
+
+	if !stdlib.DirExist(repoDir + cli.PS + gitConfDir) {
+		tester.Errorf("the clone was not moved to %v", repoDir)
+	}
+}
+
 func TestGetRepoDir2(tester *testing.T) {
 	absTestTmp, _ := filepath.Abs(TmpDir)
 	var testCases = []struct {
@@ -165,7 +221,7 @@ func TestGetLatestTag(tester *testing.T) {
 		repoPath := test.SetupARepository(tc.bundle, TmpDir, FixtureDir, cli.PS)
 
 		tester.Run(fmt.Sprintf("%v.%v", i+1, tc.name), func(t *testing.T) {
-			got, gotErr := getLatestTag(repoPath)
+			got, gotErr := getVersionTag(repoPath, cli.LatestVersion, false)
 
 			if gotErr != nil {
 				t.Errorf("unexpected error in test %q", gotErr.Error())
@@ -178,6 +234,36 @@ func TestGetLatestTag(tester *testing.T) {
 	}
 }
 
+func TestGetVersionTag(tester *testing.T) {
+	var testCases = []struct {
+		name       string
+		constraint string
+		want       string
+		shouldErr  bool
+	}{
+		{"latest", "latest", "1.0.0", false},
+		{"tilde", "~0.1", "0.1.1", false},
+		{"range", ">=0.1 <1.0", "0.2.0", false},
+		{"noMatch", "^2", "", true},
+	}
+
+	repoPath := test.SetupARepository("repo-04", TmpDir, FixtureDir, cli.PS)
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, gotErr := getVersionTag(repoPath, tc.constraint, false)
+
+			if tc.shouldErr != (gotErr != nil) {
+				t.Errorf("got error %v, want an error %v", gotErr, tc.shouldErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestGetLatestTagError(tester *testing.T) {
 	var testCases = []struct {
 		name   string
@@ -191,7 +277,7 @@ func TestGetLatestTagError(tester *testing.T) {
 		repoPath, _ := filepath.Abs("tmp")
 		repoPath += test.PS + tc.bundle
 		tester.Run(fmt.Sprintf("%v.%v", i+1, tc.name), func(t *testing.T) {
-			got, gotErr := getLatestTag(repoPath)
+			got, gotErr := getVersionTag(repoPath, cli.LatestVersion, false)
 
 			if gotErr == nil {
 				t.Errorf("unexpected error in test %q", gotErr.Error())
@@ -232,3 +318,444 @@ func TestGetRemoteTags(tester *testing.T) {
 		})
 	}
 }
+
+func TestGitVerifyUnsigned(tester *testing.T) {
+	var testCases = []struct {
+		name   string
+		bundle string
+		ref    string
+	}{
+		{"unsignedCommit", "repo-02", "main"},
+		{"unsignedTag", "repo-04", "refs/tags/1.0.0"},
+	}
+
+	for _, tc := range testCases {
+		repoPath := test.SetupARepository(tc.bundle, TmpDir, FixtureDir, cli.PS)
+
+		tester.Run(tc.name, func(t *testing.T) {
+			if gotErr := gitVerify(repoPath, tc.ref, true); gotErr == nil {
+				t.Errorf("want an error verifying unsigned %v", tc.ref)
+			}
+
+			// Only -require-signature fails on a ref that is not signed.
+			if gotErr := gitVerify(repoPath, tc.ref, false); gotErr != nil {
+				t.Errorf("got %v, want nil for unsigned %v", gotErr, tc.ref)
+			}
+		})
+	}
+}
+
+func TestGitVerifySigned(tester *testing.T) {
+	var testCases = []struct {
+		name      string
+		trusted   bool
+		ref       string
+		required  bool
+		shouldErr bool
+	}{
+		{"trustedTag", true, "refs/tags/1.0.0", false, false},
+		{"trustedCommit", true, "main", true, false},
+		{"untrustedTag", false, "refs/tags/1.0.0", false, true},
+		{"untrustedCommit", false, "main", false, true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			repoPath := makeSignedRepo(t, "signed-"+tc.name, tc.trusted)
+
+			gotErr := gitVerify(repoPath, tc.ref, tc.required)
+
+			if tc.shouldErr != (gotErr != nil) {
+				t.Errorf("got error %v, want an error %v", gotErr, tc.shouldErr)
+			}
+		})
+	}
+}
+
+func TestSetGitCredentials(tester *testing.T) {
+	defer func() { gitEnv = nil }()
+
+	creds := map[string]*cli.Credential{
+		"git.example.com": {Token: "t0ken-abcd", Headers: map[string]string{"X-Team": "t34m-header"}},
+	}
+
+	tester.Run("https", func(t *testing.T) {
+		setGitCredentials("https://git.example.com/org/repo.git", creds)
+		env := strings.Join(gitEnv, "\n")
+
+		for _, want := range []string{"TMPLTOAPP_GIT_PASSWORD=t0ken-abcd", "GIT_CONFIG_COUNT=3", "GIT_CONFIG_VALUE_2=X-Team: t34m-header"} {
+			if !strings.Contains(env, want) {
+				t.Errorf("git environment is missing %q", want)
+			}
+		}
+
+		if got := cli.Redact("running command git clone " + env); strings.Contains(got, "t0ken-abcd") || strings.Contains(got, "t34m-header") {
+			t.Errorf("credentials were not redacted from %q", got)
+		}
+	})
+
+	tester.Run("otherHost", func(t *testing.T) {
+		setGitCredentials("https://example.com/org/repo.git", creds)
+
+		if strings.Contains(strings.Join(gitEnv, "\n"), "t0ken-abcd") {
+			t.Errorf("credentials were passed to another host")
+		}
+	})
+}
+
+func TestNewGitBackend(tester *testing.T) {
+	var testCases = []struct {
+		name      string
+		backend   string
+		want      gitBackend
+		shouldErr bool
+	}{
+		{"exec", "exec", &gitExec{}, false},
+		{"go", "go", &gitPure{}, false},
+		{"auto", "auto", &gitExec{}, false},
+		{"unknown", "svn", nil, true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, gotErr := newGitBackend(tc.backend)
+
+			if tc.shouldErr != (gotErr != nil) {
+				t.Errorf("got error %v, want an error %v", gotErr, tc.shouldErr)
+			}
+
+			if fmt.Sprintf("%T", got) != fmt.Sprintf("%T", tc.want) {
+				t.Errorf("got %T, want %T", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(tester *testing.T) {
+	var testCases = []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "0.2.0", 1},
+		{"0.10.0", "0.9.0", 1},
+		{"v1.2.3", "v1.2.3", 0},
+		{"1.0", "1.0.1", -1},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			if got := compareVersions(tc.a, tc.b); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveRef(tester *testing.T) {
+	var testCases = []struct {
+		name      string
+		ref       string
+		want      string
+		shouldErr bool
+	}{
+		{"branch", "main", "3407e993abe6becbdb8fbca8beb9e9bce9565b7e", false},
+		{"fullBranchRef", "refs/heads/main", "3407e993abe6becbdb8fbca8beb9e9bce9565b7e", false},
+		{"annotatedTag", "0.1.0", "ec2e5bae82f4d955d0758223699867ab851daeba", false},
+		{"fullRef", "refs/remotes/origin/third-commit", "bfeb0a45c027420e4df286dc089965599e350bf9", false},
+		{"fullHash", "BFEB0A45C027420E4DF286DC089965599E350BF9", "bfeb0a45c027420e4df286dc089965599e350bf9", false},
+		{"abbreviatedHash", "bfeb0a4", "bfeb0a4", false},
+		{"notFound", "does-not-exist", "", true},
+	}
+
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, gotErr := resolveRef(repoPath, tc.ref)
+
+			if tc.shouldErr != (gotErr != nil) {
+				t.Fatalf("got error %v, want an error %v", gotErr, tc.shouldErr)
+			}
+
+			if got != nil && got.Commit != tc.want {
+				t.Errorf("got %v, want %v", got.Commit, tc.want)
+			}
+		})
+	}
+}
+
+// Clone any kind of ref.
+func TestGitCloneRefs(tester *testing.T) {
+	var testCases = []struct {
+		name     string
+		ref      string
+		wantHash string
+	}{
+		{"tag", "0.1.0", "ec2e5bae82f4d955d0758223699867ab851daeba"},
+		{"fullRef", "refs/remotes/origin/third-commit", "bfeb0a45c027420e4df286dc089965599e350bf9"},
+		{"fullHash", "3407e993abe6becbdb8fbca8beb9e9bce9565b7e", "3407e993abe6becbdb8fbca8beb9e9bce9565b7e"},
+		{"abbreviatedHash", "bfeb0a4", "bfeb0a45c027420e4df286dc089965599e350bf9"},
+	}
+
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			outPath := TmpDir + cli.PS + "repo-02-clone-" + tc.name
+
+			_, gotHash, err := gitClone(repoPath, outPath, tc.ref)
+
+			if err != nil {
+				t.Fatalf("got an unexpected err: %s", err)
+			}
+
+			if gotHash != tc.wantHash {
+				t.Errorf("got %v, want %v", gotHash, tc.wantHash)
+			}
+		})
+	}
+}
+
+// Fetch a single commit by its hash.
+func TestFetchCommit(tester *testing.T) {
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+	outPath := TmpDir + cli.PS + "repo-02-fetch-commit"
+	want := "bfeb0a45c027420e4df286dc089965599e350bf9"
+
+	if e := git.FetchCommit("file://"+filepath.ToSlash(repoPath), outPath, want, 1); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if got, _ := git.RevParse(outPath, "HEAD"); got != want {
+		tester.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSetGitSsh(tester *testing.T) {
+	defer func() { gitEnv = nil }()
+
+	var testCases = []struct {
+		name       string
+		repo       string
+		sshCommand string
+		sshKey     string
+		want       string
+	}{
+		{"command", "git@example.com:org/repo.git", "ssh -F /dev/null", "", "GIT_SSH_COMMAND=ssh -F /dev/null"},
+		{"key", "ssh://git@example.com/org/repo.git", "", "/home/me/.ssh/id ed25519", "GIT_SSH_COMMAND=ssh -i '/home/me/.ssh/id ed25519' -o IdentitiesOnly=yes"},
+		{"notSsh", "https://example.com/org/repo.git", "ssh -F /dev/null", "", ""},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			gitEnv = nil
+
+			if e := setGitSsh(tc.repo, tc.sshCommand, tc.sshKey); e != nil {
+				t.Fatal(e)
+			}
+
+			if got := strings.Join(gitEnv, "\n"); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// Clone over SSH, using a stand-in for ssh that runs the git command locally.
+func TestGitCloneSsh(tester *testing.T) {
+	if runtime.GOOS == "windows" {
+		tester.Skip("the ssh stand-in is a shell script")
+	}
+
+	defer func() { gitEnv = nil }()
+
+	fakeSsh, _ := filepath.Abs(TmpDir + cli.PS + "fake-ssh.sh")
+	if e := os.WriteFile(fakeSsh, []byte("#!/bin/sh\n# args: <host> <git command>\nshift\nexec sh -c \"$1\"\n"), 0755); e != nil {
+		tester.Fatal(e)
+	}
+	tester.Setenv("GIT_SSH_VARIANT", "simple")
+
+	repoPath := test.SetupARepository("repo-02", TmpDir, FixtureDir, cli.PS)
+
+	var testCases = []struct {
+		name     string
+		repo     string
+		ref      string
+		wantHash string
+	}{
+		{"scpLike", "git@example.com:" + repoPath, "0.1.0", "ec2e5bae82f4d955d0758223699867ab851daeba"},
+		{"ssh", "ssh://git@example.com" + repoPath, "3407e993abe6becbdb8fbca8beb9e9bce9565b7e", "3407e993abe6becbdb8fbca8beb9e9bce9565b7e"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			gitEnv = nil
+			if e := setGitSsh(tc.repo, fakeSsh, ""); e != nil {
+				t.Fatal(e)
+			}
+
+			outPath := TmpDir + cli.PS + "repo-02-ssh-" + tc.name
+			_, gotHash, err := gitClone(tc.repo, outPath, tc.ref)
+
+			if err != nil {
+				t.Fatalf("got an unexpected err: %s", err)
+			}
+
+			if gotHash != tc.wantHash {
+				t.Errorf("got %v, want %v", gotHash, tc.wantHash)
+			}
+
+			// Only the one commit is cloned.
+			if shallow, _ := gitCmd(outPath, "rev-parse", "--is-shallow-repository"); strings.TrimSpace(string(shallow)) != "true" {
+				t.Errorf("want a shallow clone of %v", tc.repo)
+			}
+		})
+	}
+}
+
+// makeSuperRepo Make a repository with a submodule at "shared", using git
+// directly, and return the path to it.
+func makeSuperRepo(t *testing.T, name string) string {
+	t.Setenv("GIT_AUTHOR_NAME", "tester")
+	t.Setenv("GIT_AUTHOR_EMAIL", "tester@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "tester")
+	t.Setenv("GIT_COMMITTER_EMAIL", "tester@example.com")
+	// Git only allows local submodules when asked to.
+	t.Setenv("GIT_CONFIG_PARAMETERS", "'protocol.file.allow=always'")
+
+	tmp, _ := filepath.Abs(TmpDir)
+	subDir := tmp + cli.PS + name + "-sub"
+	superDir := tmp + cli.PS + name
+
+	run := func(dir string, args ...string) {
+		if _, e := gitCmd(dir, args...); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	run(tmp, "init", "--quiet", "-b", "main", subDir)
+	if e := os.WriteFile(subDir+cli.PS+"shared.txt", []byte("{{ .appName }}\n"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	run(subDir, "add", ".")
+	run(subDir, "commit", "--quiet", "-m", "shared")
+	// The built-in backend only serves bare local repositories.
+	run(tmp, "clone", "--quiet", "--bare", subDir, subDir+".git")
+
+	run(tmp, "init", "--quiet", "-b", "main", superDir)
+	if e := os.WriteFile(superDir+cli.PS+cli.TmplManifest, []byte(`{"version": "0.1.0"}`), 0644); e != nil {
+		t.Fatal(e)
+	}
+	run(superDir, "submodule", "--quiet", "add", subDir+".git", "shared")
+	run(superDir, "add", ".")
+	run(superDir, "commit", "--quiet", "-m", "super")
+
+	return superDir
+}
+
+// makeSignedRepo Make a repository with a commit and a tag 1.0.0 signed with a
+// new SSH key, which git trusts when trusted is set.
+func makeSignedRepo(t *testing.T, name string, trusted bool) string {
+	t.Setenv("GIT_AUTHOR_NAME", "tester")
+	t.Setenv("GIT_AUTHOR_EMAIL", "tester@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "tester")
+	t.Setenv("GIT_COMMITTER_EMAIL", "tester@example.com")
+
+	tmp, _ := filepath.Abs(TmpDir)
+	repoDir := tmp + cli.PS + name
+	keyFile := repoDir + "-key"
+	_ = os.RemoveAll(repoDir)
+	_ = os.Remove(keyFile)
+	_ = os.Remove(keyFile + ".pub")
+	_ = os.MkdirAll(tmp, cli.DirMode)
+
+	if out, e := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyFile).CombinedOutput(); e != nil {
+		t.Skipf("ssh-keygen is needed to sign: %v %s", e, out)
+	}
+
+	run := func(args ...string) {
+		if _, e := gitCmd(repoDir, args...); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	if _, e := gitCmd(tmp, "init", "--quiet", "-b", "main", repoDir); e != nil {
+		t.Fatal(e)
+	}
+	run("config", "gpg.format", "ssh")
+	run("config", "user.signingkey", keyFile)
+
+	if trusted {
+		pub, _ := os.ReadFile(keyFile + ".pub")
+		signers := repoDir + "-allowed-signers"
+		if e := os.WriteFile(signers, []byte("tester@example.com "+string(pub)), 0644); e != nil {
+			t.Fatal(e)
+		}
+		run("config", "gpg.ssh.allowedSignersFile", signers)
+	}
+
+	if e := os.WriteFile(repoDir+cli.PS+cli.TmplManifest, []byte(`{"version": "1.0.0"}`), 0644); e != nil {
+		t.Fatal(e)
+	}
+	run("add", ".")
+	run("commit", "--quiet", "-S", "-m", "signed")
+	run("tag", "-s", "-m", "1.0.0", "1.0.0")
+
+	return repoDir
+}
+
+func TestGitSubmodules(tester *testing.T) {
+	repoPath := makeSuperRepo(tester, "super-01")
+	outPath := TmpDir + cli.PS + "super-01-clone"
+
+	if _, _, e := gitClone(repoPath, outPath, "main"); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if stdlib.PathExist(outPath + cli.PS + "shared" + cli.PS + "shared.txt") {
+		tester.Fatal("submodule was cloned before it was asked for")
+	}
+
+	if e := gitSubmodules(outPath, false); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !stdlib.PathExist(outPath + cli.PS + "shared" + cli.PS + "shared.txt") {
+		tester.Error("submodule was not cloned")
+	}
+}
+
+func TestGitSubmodulesOffline(tester *testing.T) {
+	testSubmodulesOffline(tester, "super-02")
+}
+
+// testSubmodulesOffline Check that offline only submodules cloned before are
+// checked out, with the remote of the submodule gone.
+func testSubmodulesOffline(t *testing.T, name string) {
+	repoPath := makeSuperRepo(t, name)
+	outPath := TmpDir + cli.PS + name + "-clone"
+
+	if _, _, e := gitClone(repoPath, outPath, "main"); e != nil {
+		t.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if e := gitSubmodules(outPath, true); e == nil {
+		t.Fatal("want an error, the submodule was never cloned")
+	}
+
+	if e := gitSubmodules(outPath, false); e != nil {
+		t.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if e := os.RemoveAll(repoPath + "-sub.git"); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := gitSubmodules(outPath, true); e != nil {
+		t.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !stdlib.PathExist(outPath + cli.PS + "shared" + cli.PS + "shared.txt") {
+		t.Error("submodule was not checked out")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+)
+
+// runTest Run the test cases of a template and print how each went, with a
+// diff of what was not expected.
+func runTest(cfg *cli.Config, w io.Writer) error {
+	fec, e1 := stdlib.NewFileExtChecker(cfg.UsrOpts.ExcludeFileExtensions, &[]string{})
+	if e1 != nil {
+		return fmt.Errorf(cli.Errors.CannotInitFileChecker, e1.Error())
+	}
+
+	results, e2 := cli.RunTemplateTests(cfg.SubCmdTest.Dir, fec, cfg.SubCmdTest.Update)
+	if e2 != nil {
+		return e2
+	}
+
+	failed := 0
+	for _, tr := range results {
+		switch {
+		case tr.Err != nil:
+			failed++
+			_, _ = fmt.Fprintf(w, "FAIL    %v: %v\n", tr.Name, tr.Err)
+		case tr.Updated:
+			_, _ = fmt.Fprintf(w, "updated %v\n", tr.Name)
+		case tr.Passed():
+			_, _ = fmt.Fprintf(w, "ok      %v\n", tr.Name)
+		default:
+			failed++
+			_, _ = fmt.Fprintf(w, "FAIL    %v\n%v", tr.Name, tr.Diff)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf(cli.Errors.TemplateTestsFailed, failed, len(results))
+	}
+
+	return nil
+}
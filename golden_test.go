@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunTest(tester *testing.T) {
+	tmplDir := TmpDir + cli.PS + "golden-01"
+	_ = os.RemoveAll(tmplDir)
+
+	cmd := runMain(tester.Name(), []string{cli.CmdInitTemplate, tmplDir})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	var testCases = []struct {
+		name     string
+		code     int
+		args     []string
+		contains string
+	}{
+		{"noGoldens", 1, []string{cli.CmdTest, tmplDir}, "+++ actual/example.txt"},
+		{"update", 0, []string{cli.CmdTest, "-update", tmplDir}, "updated default"},
+		{"pass", 0, []string{cli.CmdTest, tmplDir}, "ok      default"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cmd := runMain(tester.Name(), tc.args)
+			out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+			if got := cmd.ProcessState.ExitCode(); got != tc.code {
+				t.Errorf("got %v, want %v: %s", got, tc.code, out)
+			}
+
+			if !strings.Contains(string(out), tc.contains) {
+				t.Errorf("got %s, want it to contain %q", out, tc.contains)
+			}
+		})
+	}
+}
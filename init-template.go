@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+)
+
+// runInitTemplate Make a new template and print the files made.
+func runInitTemplate(cfg *cli.Config, w io.Writer) error {
+	fec, e1 := stdlib.NewFileExtChecker(cfg.UsrOpts.ExcludeFileExtensions, &[]string{})
+	if e1 != nil {
+		return fmt.Errorf(cli.Errors.CannotInitFileChecker, e1.Error())
+	}
+
+	made, e2 := cli.InitTemplate(cfg.SubCmdInitTemplate.Dir, cfg.SubCmdInitTemplate.From, fec)
+	if e2 != nil {
+		return e2
+	}
+
+	_, _ = fmt.Fprintf(w, "made a template in %v\n", cfg.SubCmdInitTemplate.Dir)
+	for _, f := range made {
+		_, _ = fmt.Fprintf(w, "  %v\n", f)
+	}
+
+	return nil
+}
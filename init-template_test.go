@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"testing"
+)
+
+func TestInitTemplate(tester *testing.T) {
+	tmplDir := TmpDir + cli.PS + "init-template-01"
+	outDir := TmpDir + cli.PS + "app-init-template-01"
+	_ = os.RemoveAll(tmplDir)
+	_ = os.RemoveAll(outDir)
+
+	cmd := runMain(tester.Name(), []string{cli.CmdInitTemplate, tmplDir})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	// The new template makes a project with its own test answers.
+	cmd = runMain(tester.Name(), []string{
+		"-answer-path", tmplDir + cli.PS + cli.TestAnswers,
+		"-tmpl-path", tmplDir,
+		"-out-path", outDir,
+		"-tmpl-type", "dir",
+	})
+	out, _ = test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	readme, _ := os.ReadFile(outDir + cli.PS + "README.md")
+	if want := "# Example App\n"; string(readme[:len(want)]) != want {
+		tester.Errorf("got %q, want it to start with %q", readme, want)
+	}
+
+	for _, f := range []string{"TEMPLATE.md", cli.TestsDir, cli.IgnoreFile} {
+		if stdlib.PathExist(outDir + cli.PS + f) {
+			tester.Errorf("got %v in the project, want it left out", f)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// runInspect Get a template like a normal run and print what it needs.
+func runInspect(cfg *cli.Config, w io.Writer) error {
+	if e := resolveTemplate(cfg); e != nil {
+		return e
+	}
+
+	c, e1 := cli.Inspect(cfg.Tmpl, cfg.TmplJson)
+	if e1 != nil {
+		return e1
+	}
+
+	c.Source = cli.RedactUrl(cfg.TmplPath)
+	if cfg.TmplType == "git" {
+		c.Ref = cfg.Branch
+		c.Commit = cfg.TmplCommit
+	}
+
+	if cfg.SubCmdInspect.Format == "json" {
+		data, e2 := json.MarshalIndent(c, "", "    ")
+		if e2 != nil {
+			return e2
+		}
+		_, e3 := fmt.Fprintf(w, "%s\n", data)
+		return e3
+	}
+
+	return printContract(c, w)
+}
+
+// printContract Print the contract of a template for people.
+func printContract(c *cli.Contract, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Template:\t%v\n", c.Source)
+	if c.Ref != "" {
+		_, _ = fmt.Fprintf(tw, "Ref:\t%v\n", c.Ref)
+	}
+	if c.Commit != "" {
+		_, _ = fmt.Fprintf(tw, "Commit:\t%v\n", c.Commit)
+	}
+	_, _ = fmt.Fprintf(tw, "Version:\t%v\n", c.Version)
+	if c.Description != "" {
+		_, _ = fmt.Fprintf(tw, "Description:\t%v\n", c.Description)
+	}
+	_, _ = fmt.Fprintf(tw, "Files:\t%v\n", c.Files)
+
+	if e := tw.Flush(); e != nil {
+		return e
+	}
+
+	_, _ = fmt.Fprintf(w, "\nPlaceholders (%d):\n", len(c.Placeholders))
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, p := range c.Placeholders {
+		_, _ = fmt.Fprintf(tw, "  %v\t%v", p.Name, p.Prompt)
+		if len(p.Rules) > 0 {
+			_, _ = fmt.Fprintf(tw, "\t(%v)", strings.Join(p.Rules, ", "))
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+	if e := tw.Flush(); e != nil {
+		return e
+	}
+
+	if len(c.Validation) > 0 {
+		_, _ = fmt.Fprintf(w, "\nValidation:\n")
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, r := range c.Validation {
+			_, _ = fmt.Fprintf(tw, "  %v\t%v", strings.Join(r.Fields, ", "), r.Rule)
+			if r.Expression != "" || r.Message != "" {
+				_, _ = fmt.Fprintf(tw, "\t%v\t%v", r.Expression, r.Message)
+			}
+			_, _ = fmt.Fprintln(tw)
+		}
+		if e := tw.Flush(); e != nil {
+			return e
+		}
+	}
+
+	printList(w, "Excludes, copied as-is", c.Excludes)
+	printList(w, "Skip", c.Skip)
+
+	return nil
+}
+
+// printList Print a titled list, nothing when it is empty.
+func printList(w io.Writer, title string, list []string) {
+	if len(list) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%v:\n", title)
+	for _, item := range list {
+		_, _ = fmt.Fprintf(w, "  %v\n", item)
+	}
+}
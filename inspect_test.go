@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunInspect(tester *testing.T) {
+	tmplDir := filepath.Join("internal", "cli", FixtureDir, "inspect-01")
+
+	var testCases = []struct {
+		name     string
+		format   string
+		contains []string
+	}{
+		{"text", "text", []string{"Version:     1.2.0", "codeName  Name of the app in code         (regExp, alphaNumeric)", "Skip:\n  docs/drafts"}},
+		{"json", "json", []string{`"version": "1.2.0"`, `"files": 2`, `"expression": "^[a-z][a-z0-9-]*$"`}},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cfg := &cli.Config{Tmpl: tmplDir, TmplPath: tmplDir, TmplType: "dir", UsrOpts: &cli.UserOptions{}}
+			cfg.SubCmdInspect.Format = tc.format
+			out := &bytes.Buffer{}
+
+			if e := runInspect(cfg, out); e != nil {
+				t.Fatalf("got an unexpected err: %s", e)
+			}
+
+			for _, want := range tc.contains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("got %s, want it to contain %q", out, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInspectGitTemplate(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+	tmplPath := test.SetupARepository(fixture, TmpDir+test.PS+"inspect-remotes", FixtureDir, test.PS)
+
+	cmd := runMain(tester.Name(), []string{cli.CmdInspect, "-format", "json", "-ref", "main", tmplPath})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	start := bytes.IndexByte(out, '{')
+	end := bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		tester.Fatalf("got %s, want a JSON contract", out)
+	}
+
+	c := &cli.Contract{}
+	if e := json.Unmarshal(out[start:end+1], c); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if c.Commit != "b437757d8da54ada2e622996af0911ac5697242b" || c.Ref != "main" || len(c.Placeholders) != 3 {
+		tester.Errorf("got %+v, want the contract of %v at main", c, fixture)
+	}
+}
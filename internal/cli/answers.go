@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+const (
+	// answersComment Key of the prompts in an answer file, ignored when it is
+	// loaded.
+	answersComment = "$comment"
+	// obsoleteComment Prompt of an answer that the template no longer asks for.
+	obsoleteComment = "obsolete, this is no longer a placeholder of the template"
+)
+
+// AnswersChanges What was done to an answer file.
+type AnswersChanges struct {
+	Added    []string // Placeholders added, without a value.
+	Obsolete []string // Answers to placeholders the template does not have.
+}
+
+// WriteAnswers Save an answer file with every placeholder of a template,
+// without a value, and its prompt under "$comment". With merge, an answer
+// file that exists is updated; missing placeholders are added and answers the
+// template no longer asks for are kept, but flagged in "$comment".
+func WriteAnswers(file string, tj *TmplJson, merge bool) (*AnswersChanges, error) {
+	doc := map[string]json.RawMessage{}
+	answers := tmplVars{}
+
+	content, e1 := os.ReadFile(file)
+	switch {
+	case e1 == nil && !merge:
+		return nil, fmt.Errorf(Errors.AnswersExist, file)
+	case e1 == nil:
+		if e := json.Unmarshal(content, &doc); e != nil {
+			return nil, fmt.Errorf(Errors.CannotDecodeAnswerFile, file, e.Error())
+		}
+		if p, ok := doc["placeholders"]; ok {
+			if e := json.Unmarshal(p, &answers); e != nil {
+				return nil, fmt.Errorf(Errors.CannotDecodeAnswerFile, file, e.Error())
+			}
+		}
+	case !os.IsNotExist(e1):
+		return nil, fmt.Errorf(Errors.CannotReadAnswerFile, file, e1.Error())
+	}
+
+	changes := &AnswersChanges{Added: []string{}, Obsolete: []string{}}
+	comments := make(map[string]string, len(tj.Placeholders))
+
+	for name, prompt := range tj.Placeholders {
+		comments[name] = prompt
+		if _, ok := answers[name]; !ok {
+			answers[name] = ""
+			changes.Added = append(changes.Added, name)
+		}
+	}
+
+	for name := range answers {
+		if _, ok := tj.Placeholders[name]; !ok {
+			comments[name] = obsoleteComment
+			changes.Obsolete = append(changes.Obsolete, name)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Obsolete)
+
+	c, e2 := json.Marshal(comments)
+	if e2 != nil {
+		return nil, fmt.Errorf(Errors.encodingJson, file, e2.Error())
+	}
+
+	a, e3 := json.Marshal(answers)
+	if e3 != nil {
+		return nil, fmt.Errorf(Errors.encodingJson, file, e3.Error())
+	}
+
+	doc[answersComment] = c
+	doc["placeholders"] = a
+
+	if e := writeJsonFile(file, doc); e != nil {
+		return nil, e
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteAnswers(tester *testing.T) {
+	file := TmpDir + PS + "answers-skeleton-01.json"
+	_ = os.Remove(file)
+	tj := &TmplJson{Placeholders: tmplVars{"appName": "Name of the app", "repoOrg": "GitHub org"}}
+
+	got, err := WriteAnswers(file, tj, false)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if strings.Join(got.Added, ",") != "appName,repoOrg" || len(got.Obsolete) != 0 {
+		tester.Errorf("got %+v, want appName and repoOrg added", got)
+	}
+
+	doc := struct {
+		Comment      map[string]string `json:"$comment"`
+		Placeholders map[string]string `json:"placeholders"`
+	}{}
+	content, _ := os.ReadFile(file)
+	if e := json.Unmarshal(content, &doc); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if doc.Comment["repoOrg"] != "GitHub org" || doc.Placeholders["repoOrg"] != "" {
+		tester.Errorf("got %s, want the prompt of repoOrg and no value", content)
+	}
+
+	// The skeleton is a valid answer file.
+	if _, e := LoadAnswers(file); e != nil {
+		tester.Errorf("got an unexpected err: %s", e)
+	}
+
+	if _, e := WriteAnswers(file, tj, false); e == nil {
+		tester.Errorf("got no error, want one when the answer file exists")
+	}
+}
+
+func TestWriteAnswersMerge(tester *testing.T) {
+	file := TmpDir + PS + "answers-skeleton-02.json"
+	_ = os.WriteFile(file, []byte(`{"placeholders": {"appName": "web", "oldName": "x"}}`), 0644)
+	tj := &TmplJson{Placeholders: tmplVars{"appName": "Name of the app", "repoOrg": "GitHub org"}}
+
+	got, err := WriteAnswers(file, tj, true)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if strings.Join(got.Added, ",") != "repoOrg" || strings.Join(got.Obsolete, ",") != "oldName" {
+		tester.Errorf("got %+v, want repoOrg added and oldName obsolete", got)
+	}
+
+	aj, e2 := LoadAnswers(file)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if aj.Placeholders["appName"] != "web" || aj.Placeholders["oldName"] != "x" {
+		tester.Errorf("got %v, want the answers kept", aj.Placeholders)
+	}
+
+	content, _ := os.ReadFile(file)
+	if !strings.Contains(string(content), `"oldName": "`+obsoleteComment+`"`) {
+		tester.Errorf("got %s, want oldName flagged as obsolete", content)
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ReadBatch Read the rows of a data set, each is the answers for one run of a
+// template. A CSV file has a header with the placeholder names, a JSONL file
+// has an object on each line.
+func ReadBatch(file string) ([]map[string]string, error) {
+	f, e1 := os.Open(file)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotReadFile, file, e1.Error())
+	}
+	defer f.Close()
+
+	var rows []map[string]string
+	var e2 error
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		rows, e2 = readCsvRows(f)
+	case ".jsonl", ".ndjson":
+		rows, e2 = readJsonlRows(f)
+	default:
+		return nil, fmt.Errorf(Errors.BadBatchFile, file)
+	}
+
+	if e2 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, file, e2.Error())
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf(Errors.BatchEmpty, file)
+	}
+
+	return rows, nil
+}
+
+// BatchOutPath Fill in an output path pattern, such as "out/{{.clientId}}",
+// with the answers of a row.
+func BatchOutPath(pattern string, row map[string]string) (string, error) {
+	t, e1 := template.New("out-path").Option("missingkey=error").Parse(pattern)
+	if e1 != nil {
+		return "", fmt.Errorf(Errors.BadOutPathPattern, pattern, e1.Error())
+	}
+
+	buf := &bytes.Buffer{}
+	if e := t.Execute(buf, row); e != nil {
+		return "", fmt.Errorf(Errors.BadOutPathPattern, pattern, e.Error())
+	}
+
+	out := strings.TrimSpace(buf.String())
+	if out == "" {
+		return "", fmt.Errorf(Errors.BadOutPathPattern, pattern, "it is empty")
+	}
+
+	return filepath.Clean(out), nil
+}
+
+// readCsvRows Read CSV records in to rows keyed by the header.
+func readCsvRows(r io.Reader) ([]map[string]string, error) {
+	records, e1 := csv.NewReader(r).ReadAll()
+	if e1 != nil {
+		return nil, e1
+	}
+
+	if len(records) < 1 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[strings.TrimSpace(name)] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// readJsonlRows Read a JSON object from each line that is not blank. Values
+// that are not strings, such as numbers, are kept as written.
+func readJsonlRows(r io.Reader) ([]map[string]string, error) {
+	rows := []map[string]string{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), MaxTplSize)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		obj := map[string]json.RawMessage{}
+		if e := json.Unmarshal(line, &obj); e != nil {
+			return nil, fmt.Errorf("line %d: %v", n, e.Error())
+		}
+
+		row := make(map[string]string, len(obj))
+		for name, raw := range obj {
+			var s string
+			if e := json.Unmarshal(raw, &s); e != nil {
+				s = string(raw)
+			}
+			row[name] = s
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, scanner.Err()
+}
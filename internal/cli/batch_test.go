@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadBatch(tester *testing.T) {
+	_ = os.MkdirAll(TmpDir, DirMode)
+	csvFile := TmpDir + PS + "batch-01.csv"
+	jsonlFile := TmpDir + PS + "batch-01.jsonl"
+	_ = os.WriteFile(csvFile, []byte("appName,clientId\nAcme,acme\n\"Globex, Inc\",globex\n"), 0644)
+	_ = os.WriteFile(jsonlFile, []byte("{\"appName\": \"Acme\", \"clientId\": \"acme\"}\n\n{\"appName\": \"Globex, Inc\", \"clientId\": 7}\n"), 0644)
+
+	var testCases = []struct {
+		name string
+		file string
+		want string
+	}{
+		{"csv", csvFile, "globex"},
+		{"jsonl", jsonlFile, "7"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := ReadBatch(tc.file)
+
+			if err != nil {
+				t.Fatalf("got an unexpected err: %s", err)
+			}
+
+			if len(got) != 2 || got[0]["appName"] != "Acme" || got[1]["appName"] != "Globex, Inc" || got[1]["clientId"] != tc.want {
+				t.Errorf("got %v, want 2 rows", got)
+			}
+		})
+	}
+}
+
+func TestReadBatchErrors(tester *testing.T) {
+	_ = os.MkdirAll(TmpDir, DirMode)
+	empty := TmpDir + PS + "batch-02.csv"
+	badJson := TmpDir + PS + "batch-02.jsonl"
+	_ = os.WriteFile(empty, []byte("appName,clientId\n"), 0644)
+	_ = os.WriteFile(badJson, []byte("{\"appName\": \"Acme\"}\n{appName}\n"), 0644)
+
+	var testCases = []struct {
+		name string
+		file string
+	}{
+		{"badExt", FixtureDir + PS + "config-01.json"},
+		{"noRows", empty},
+		{"badJson", badJson},
+		{"notFound", TmpDir + PS + "batch-404.csv"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			if _, err := ReadBatch(tc.file); err == nil {
+				t.Errorf("got no error, want one")
+			}
+		})
+	}
+}
+
+func TestBatchOutPath(tester *testing.T) {
+	row := map[string]string{"clientId": "acme"}
+
+	var testCases = []struct {
+		name    string
+		pattern string
+		want    string
+		wantErr bool
+	}{
+		{"filled", "out/{{.clientId}}", "out" + PS + "acme", false},
+		{"missing", "out/{{.appName}}", "", true},
+		{"empty", "{{if false}}x{{end}}", "", true},
+		{"bad", "out/{{.clientId", "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := BatchOutPath(tc.pattern, row)
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got err %v, want an error %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
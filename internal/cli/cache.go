@@ -0,0 +1,508 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib/log"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CacheIndex Name of the file, in the cache, that maps the name of each
+	// entry to its template location and ref.
+	CacheIndex = "index.json"
+	// cacheEntryExt Extension of the metadata file kept next to each cache entry.
+	cacheEntryExt = ".cache.json"
+	// cacheHashLen Number of hex characters of the hash in a cache key.
+	cacheHashLen = 16
+)
+
+var (
+	// reCacheName The name of a cache entry made by CacheKey, or by
+	// DownloadName without the ".zip" extension.
+	reCacheName   = regexp.MustCompile(fmt.Sprintf(`^[A-Za-z0-9._-]*-[0-9a-f]{%d}$`, cacheHashLen))
+	reCacheUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	reSize        = regexp.MustCompile(`^(?i)\s*(\d+(?:\.\d+)?)\s*([kmgt]?)i?b?\s*$`)
+)
+
+// cacheIndexEntry What the index records about a cache entry.
+type cacheIndexEntry struct {
+	Ref    string `json:"ref,omitempty"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+// CacheEntry A template in the cache; a git clone or a downloaded zip along
+// with its extracted directory.
+type CacheEntry struct {
+	Commit   string    `json:"commit,omitempty"` // Commit of a git template that was last checked out.
+	Created  time.Time `json:"created"`
+	LastUsed time.Time `json:"lastUsed"`
+	Mirror   bool      `json:"mirror,omitempty"` // Imported from a mirror, used without going to the network.
+	Name     string    `json:"name"`             // File or directory name of the entry in the cache.
+	Ref      string    `json:"ref,omitempty"`    // Ref of a git template.
+	Source   string    `json:"source"`           // Location of the template, redacted.
+	Type     string    `json:"type"`             // Type of template; zip or git.
+	Size     int64     `json:"-"`                // Bytes used by the entry, set when listing.
+}
+
+// paths Files and directories that belong to an entry, without its metadata.
+func (ce *CacheEntry) paths(cacheDir string) []string {
+	p := cacheDir + PS + ce.Name
+	paths := []string{p}
+
+	snapshots, _ := filepath.Glob(SnapshotPath(cacheDir, ce.Name, "*"))
+	paths = append(paths, snapshots...)
+
+	if ce.Type == "zip" {
+		// The download metadata, a partial download and the extracted zip.
+		paths = append(paths, p+metaExt, p+partExt)
+		if dir := strings.TrimSuffix(p, ".zip"); dir != p {
+			paths = append(paths, dir)
+		}
+	}
+
+	return paths
+}
+
+// CacheKey Name of the cache entry for a template at a ref. The base name of
+// the template and the ref are there for people, the hash of the normalized
+// location and ref keeps "github.com/a/web" and "github.com/b/web" apart.
+func CacheKey(source, ref string) string {
+	norm := normalizeSource(source)
+
+	parts := []string{cacheSafe(strings.TrimSuffix(path.Base(norm), ".git"))}
+	if ref != "" {
+		parts = append(parts, cacheSafe(ref))
+	}
+	parts = append(parts, cacheHash(norm+"\n"+ref))
+
+	return strings.Join(parts, "-")
+}
+
+// SameSource Check if two template locations are the same after they are
+// normalized, for example "git@github.com:org/web.git" and
+// "https://github.com/org/web".
+func SameSource(a, b string) bool {
+	return normalizeSource(a) == normalizeSource(b)
+}
+
+// ClearCache Remove everything in the cache, but the locks and the entries
+// other processes have locked.
+func ClearCache(cacheDir string) error {
+	entries, e1 := readCacheEntries(cacheDir)
+	if e1 != nil {
+		return e1
+	}
+
+	for _, ce := range entries {
+		if _, e := RemoveCacheEntry(cacheDir, ce); e != nil {
+			return e
+		}
+	}
+
+	// Entries with no metadata yet, such as a clone in progress, only have a lock.
+	locks, e2 := filepath.Glob(cacheDir + PS + "*" + lockExt)
+	if e2 != nil {
+		return e2
+	}
+
+	inUse := []string{}
+	for _, lock := range locks {
+		name := strings.TrimSuffix(filepath.Base(lock), lockExt)
+
+		unlock, e3 := TryLockCacheEntry(cacheDir, name)
+		if e3 != nil {
+			return e3
+		}
+
+		if unlock == nil {
+			log.Logf(Messages.CacheInUse, name)
+			inUse = append(inUse, name)
+			continue
+		}
+		defer unlock()
+	}
+
+	if e := clearFiles(cacheDir, inUse); e != nil {
+		return e
+	}
+
+	if len(inUse) > 0 {
+		return writeCacheIndex(cacheDir)
+	}
+
+	return nil
+}
+
+// FindCacheEntries Return the entries of a template, the most recently used
+// first, matched by its source or the name of the entry. Their size is not
+// set, see MeasureCache.
+func FindCacheEntries(cacheDir, source string) ([]*CacheEntry, error) {
+	entries, e1 := readCacheEntries(cacheDir)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	found := []*CacheEntry{}
+	for _, ce := range entries {
+		if ce.Name == source || ce.Source == RedactUrl(source) || SameSource(ce.Source, source) {
+			found = append(found, ce)
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, fmt.Errorf(Errors.CacheEntryNotFound, source)
+	}
+
+	return found, nil
+}
+
+// FormatSize Format a number of bytes for people, such as "1.5 MB".
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// ListCache Return the entries in the cache, the most recently used first,
+// with their size. Only entries with metadata are listed.
+func ListCache(cacheDir string) ([]*CacheEntry, error) {
+	entries, e1 := readCacheEntries(cacheDir)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	MeasureCache(cacheDir, entries)
+
+	return entries, nil
+}
+
+// MeasureCache Set the bytes used by each entry. It goes over all their
+// files, so only do it to show or limit the size of the cache.
+func MeasureCache(cacheDir string, entries []*CacheEntry) {
+	for _, ce := range entries {
+		ce.Size = 0
+		for _, p := range ce.paths(cacheDir) {
+			ce.Size += pathSize(p)
+		}
+	}
+}
+
+// ParseAge Parse an age such as "30d", "12h" or "90m".
+func ParseAge(age string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(age, "d"); ok {
+		n, e := strconv.ParseFloat(days, 64)
+		if e != nil || n < 0 {
+			return 0, fmt.Errorf(Errors.InvalidAge, age)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+
+	d, e := time.ParseDuration(age)
+	if e != nil || d < 0 {
+		return 0, fmt.Errorf(Errors.InvalidAge, age)
+	}
+
+	return d, nil
+}
+
+// ParseSize Parse a size such as "500MB", "2G" or "1024", in bytes.
+func ParseSize(size string) (int64, error) {
+	m := reSize.FindStringSubmatch(size)
+	if m == nil {
+		return 0, fmt.Errorf(Errors.InvalidSize, size)
+	}
+
+	n, _ := strconv.ParseFloat(m[1], 64)
+	exp := 0
+	if m[2] != "" {
+		exp = strings.Index("kmgt", strings.ToLower(m[2])) + 1
+	}
+
+	for ; exp > 0; exp-- {
+		n *= 1024
+	}
+
+	return int64(n), nil
+}
+
+// PruneCache Remove the entries not used for longer than maxAge, then the
+// least recently used entries until the cache is no bigger than maxSize. A
+// zero maxAge or maxSize is no limit. Returns the entries removed.
+func PruneCache(cacheDir string, maxAge time.Duration, maxSize int64, now time.Time) ([]*CacheEntry, error) {
+	entries, e1 := ListCache(cacheDir)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	var total int64
+	for _, ce := range entries {
+		total += ce.Size
+	}
+
+	removed := []*CacheEntry{}
+
+	// Go from the least recently used.
+	for i := len(entries) - 1; i >= 0; i-- {
+		ce := entries[i]
+		tooOld := maxAge > 0 && now.Sub(ce.LastUsed) > maxAge
+		tooBig := maxSize > 0 && total > maxSize
+
+		if !tooOld && !tooBig {
+			continue
+		}
+
+		ok, e := RemoveCacheEntry(cacheDir, ce)
+		if e != nil {
+			return removed, e
+		}
+
+		if !ok {
+			continue
+		}
+
+		total -= ce.Size
+		removed = append(removed, ce)
+	}
+
+	return removed, nil
+}
+
+// RemoveCacheEntry Remove an entry, and its metadata, from the cache. An
+// entry locked by another process is in use, it is left alone and false is
+// returned.
+func RemoveCacheEntry(cacheDir string, ce *CacheEntry) (bool, error) {
+	unlock, e1 := TryLockCacheEntry(cacheDir, ce.Name)
+	if e1 != nil {
+		return false, e1
+	}
+
+	if unlock == nil {
+		log.Logf(Messages.CacheInUse, ce.Name)
+		return false, nil
+	}
+	defer unlock()
+
+	for _, p := range ce.paths(cacheDir) {
+		if e := os.RemoveAll(p); e != nil {
+			return false, fmt.Errorf(Errors.CacheRemove, p, e.Error())
+		}
+	}
+
+	file := cacheDir + PS + ce.Name + cacheEntryExt
+	if e := os.Remove(file); e != nil && !os.IsNotExist(e) {
+		return false, fmt.Errorf(Errors.CacheRemove, file, e.Error())
+	}
+
+	log.Infof(Messages.CacheRemoved, ce.Name)
+
+	return true, writeCacheIndex(cacheDir)
+}
+
+// TouchCacheEntry Save the metadata of an entry, as just used. What was
+// saved before and is not given again, such as when it was created, that it
+// came from a mirror or its commit, is kept.
+func TouchCacheEntry(cacheDir string, ce *CacheEntry) error {
+	file := cacheDir + PS + ce.Name + cacheEntryExt
+
+	ce.Source = RedactUrl(ce.Source)
+	ce.LastUsed = time.Now().UTC()
+	ce.Created = ce.LastUsed
+
+	if prev, e := readCacheEntry(file); e == nil {
+		if !prev.Created.IsZero() {
+			ce.Created = prev.Created
+		}
+		if ce.Commit == "" {
+			ce.Commit = prev.Commit
+		}
+		ce.Mirror = ce.Mirror || prev.Mirror
+	}
+
+	data, e1 := json.MarshalIndent(ce, "", "    ")
+	if e1 != nil {
+		return fmt.Errorf(Errors.encodingJson, file, e1.Error())
+	}
+
+	if e := writeFileAtomic(file, data, 0644); e != nil {
+		return fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+	}
+
+	return writeCacheIndex(cacheDir)
+}
+
+// cacheHash Hash a string for a cache key.
+func cacheHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])[:cacheHashLen]
+}
+
+// belongsTo Check if a file in the cache belongs to one of the entries
+// named; it is the entry, its download, extraction, metadata or a snapshot.
+func belongsTo(file string, names []string) bool {
+	for _, name := range names {
+		name = strings.TrimSuffix(name, ".zip")
+		if file == name || strings.HasPrefix(file, name+".") || strings.HasPrefix(file, name+"@") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clearFiles Remove the files in a directory of the cache, but the locks and
+// those that belong to the entries in use.
+func clearFiles(dir string, inUse []string) error {
+	files, e1 := os.ReadDir(dir)
+	if e1 != nil {
+		return e1
+	}
+
+	for _, f := range files {
+		p := dir + PS + f.Name()
+
+		if strings.HasSuffix(f.Name(), lockExt) || belongsTo(f.Name(), inUse) {
+			continue
+		}
+
+		// Keep the snapshots of the entries in use.
+		if f.Name() == snapshotDir && len(inUse) > 0 {
+			if e := clearFiles(p, inUse); e != nil {
+				return e
+			}
+			continue
+		}
+
+		if e := os.RemoveAll(p); e != nil {
+			return fmt.Errorf(Errors.CacheRemove, p, e.Error())
+		}
+	}
+
+	return nil
+}
+
+// isCacheName Check if a name is one the cache gives to an entry of a type of
+// template, and so cannot be "..", the index or any other file in the cache.
+func isCacheName(name, tmplType string) bool {
+	if tmplType == "zip" && strings.EqualFold(filepath.Ext(name), ".zip") {
+		name = name[:len(name)-len(".zip")]
+	}
+
+	return reCacheName.MatchString(name)
+}
+
+// cacheSafe Replace characters that do not belong in a file name.
+func cacheSafe(s string) string {
+	return strings.Trim(reCacheUnsafe.ReplaceAllString(s, "-"), "-")
+}
+
+// pathSize Bytes used by a file, or all files in a directory.
+func pathSize(path string) int64 {
+	var size int64
+
+	_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info, e := d.Info(); e == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// readCacheEntries Read the metadata of the entries in the cache, the most
+// recently used first.
+func readCacheEntries(cacheDir string) ([]*CacheEntry, error) {
+	files, e1 := filepath.Glob(cacheDir + PS + "*" + cacheEntryExt)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	entries := make([]*CacheEntry, 0, len(files))
+	for _, file := range files {
+		ce, e2 := readCacheEntry(file)
+		if e2 != nil {
+			log.Dbugf(e2.Error())
+			continue
+		}
+
+		entries = append(entries, ce)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+
+	return entries, nil
+}
+
+// readCacheEntry Read the metadata of a cache entry.
+func readCacheEntry(file string) (*CacheEntry, error) {
+	content, e1 := os.ReadFile(file)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	ce := &CacheEntry{}
+	if e := json.Unmarshal(content, ce); e != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, file, e.Error())
+	}
+
+	if ce.Name == "" {
+		ce.Name = strings.TrimSuffix(filepath.Base(file), cacheEntryExt)
+	}
+
+	return ce, nil
+}
+
+// writeCacheIndex Save the index of the cache entries, so people can tell
+// which template an entry is for without reading each metadata file.
+func writeCacheIndex(cacheDir string) error {
+	entries, e1 := readCacheEntries(cacheDir)
+	if e1 != nil {
+		return e1
+	}
+
+	index := make(map[string]cacheIndexEntry, len(entries))
+	for _, ce := range entries {
+		index[ce.Name] = cacheIndexEntry{Ref: ce.Ref, Source: ce.Source, Type: ce.Type}
+	}
+
+	file := cacheDir + PS + CacheIndex
+	data, e2 := json.MarshalIndent(index, "", "    ")
+	if e2 != nil {
+		return fmt.Errorf(Errors.encodingJson, file, e2.Error())
+	}
+
+	if e := writeFileAtomic(file, data, 0644); e != nil {
+		return fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	checksumExt      = ".sha256"
+	checksumFragment = "sha256="
+	checksumPrefix   = "sha256-"
+)
+
+var reSha256 = regexp.MustCompile("^[a-f0-9]{64}$")
+
+// ChecksumError A downloaded template did not match the expected SHA-256 digest.
+type ChecksumError struct {
+	File string // File that was verified.
+	Want string // Expected hex encoded digest.
+	Got  string // Actual hex encoded digest.
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf(Errors.ChecksumMismatch, e.File, e.Want, e.Got)
+}
+
+// DownloadVerified Download a template and verify its SHA-256 checksum before
+// it can be extracted.
+//
+// The expected checksum is taken from sum, then from a "#sha256=<hex>" URL
+// fragment, and lastly from a sibling "<url>.sha256" file on the server. When
+// none are found the download is NOT verified. A verified download is saved
+// in the cache under its digest and reused on later runs.
+func DownloadVerified(url, sum, dstDir string, client Client) (string, error) {
+	url, fragSum := ParseChecksumFragment(url)
+
+	if sum == "" {
+		sum = fragSum
+	}
+
+	if sum == "" {
+		// The checksum file is optional, one that cannot be used is the same
+		// as none.
+		s, e1 := FetchChecksum(url, client)
+		if e1 != nil {
+			log.Infof(Messages.ChecksumFileUnusable, Redact(url+checksumExt), e1.Error())
+		}
+		sum = s
+	}
+
+	if sum == "" {
+		log.Infof(Messages.NoChecksum, Redact(url))
+		return Download(url, dstDir, client)
+	}
+
+	sum, e2 := normalizeChecksum(sum)
+	if e2 != nil {
+		return "", e2
+	}
+
+	cached := dstDir + PS + checksumPrefix + sum + ".zip"
+	if stdlib.PathExist(cached) {
+		if e := VerifyChecksum(cached, sum); e == nil {
+			log.Infof(Messages.UsingCachedDownload, cached)
+			return cached, nil
+		}
+		// The cache has been tampered with or is corrupt, so get a fresh copy.
+		_ = os.Remove(cached)
+	}
+
+	zipFile, e3 := Download(url, dstDir, client)
+	if e3 != nil {
+		return "", e3
+	}
+
+	if e := VerifyChecksum(zipFile, sum); e != nil {
+		_ = os.Remove(zipFile)
+		return "", e
+	}
+
+	if e := os.Rename(zipFile, cached); e != nil {
+		return "", e
+	}
+
+	return cached, nil
+}
+
+// FetchChecksum Look for a sibling "<url>.sha256" file and return the digest
+// it contains, or an empty string when there is none. The digest is empty
+// whenever there is an error.
+func FetchChecksum(url string, client Client) (string, error) {
+	resp, e1 := client.Get(url + checksumExt)
+	if e1 != nil {
+		return "", e1
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Dbugf(Messages.ChecksumFileStatus, Redact(url+checksumExt), resp.StatusCode)
+		return "", nil
+	}
+
+	content, e2 := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	if e2 != nil {
+		return "", e2
+	}
+
+	// Format is the output of sha256sum, "<hex>  <filename>", or just "<hex>".
+	fields := strings.Fields(string(content))
+	if len(fields) < 1 {
+		return "", nil
+	}
+
+	return normalizeChecksum(fields[0])
+}
+
+// FileSha256 Return the hex encoded SHA-256 digest of a file.
+func FileSha256(file string) (string, error) {
+	f, e1 := os.Open(file)
+	if e1 != nil {
+		return "", e1
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, e := io.Copy(h, f); e != nil {
+		return "", e
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseChecksumFragment Split a "#sha256=<hex>" fragment from a URL, returning
+// the URL without the fragment and the checksum (if any).
+func ParseChecksumFragment(url string) (string, string) {
+	i := strings.LastIndex(url, "#")
+	if i < 0 {
+		return url, ""
+	}
+
+	fragment := url[i+1:]
+	if !strings.HasPrefix(fragment, checksumFragment) {
+		return url, ""
+	}
+
+	return url[:i], strings.TrimPrefix(fragment, checksumFragment)
+}
+
+// VerifyChecksum Compare the SHA-256 digest of a file to the one expected.
+func VerifyChecksum(file, want string) error {
+	want, e1 := normalizeChecksum(want)
+	if e1 != nil {
+		return e1
+	}
+
+	got, e2 := FileSha256(file)
+	if e2 != nil {
+		return e2
+	}
+
+	if got != want {
+		return &ChecksumError{File: file, Want: want, Got: got}
+	}
+
+	log.Infof(Messages.ChecksumVerified, file)
+
+	return nil
+}
+
+// normalizeChecksum Lowercase and validate a hex encoded SHA-256 digest.
+func normalizeChecksum(sum string) (string, error) {
+	sum = strings.ToLower(strings.TrimSpace(sum))
+
+	if !reSha256.MatchString(sum) {
+		return "", fmt.Errorf(Errors.InvalidChecksum, sum)
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestParseChecksumFragment(tester *testing.T) {
+	var testCases = []struct {
+		name, url, wantUrl, wantSum string
+	}{
+		{"none", "https://example.com/t.zip", "https://example.com/t.zip", ""},
+		{"sha256", "https://example.com/t.zip#sha256=abc", "https://example.com/t.zip", "abc"},
+		{"otherFragment", "https://example.com/t.zip#readme", "https://example.com/t.zip#readme", ""},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			gotUrl, gotSum := ParseChecksumFragment(tc.url)
+
+			if gotUrl != tc.wantUrl {
+				t.Errorf("got %v, want %v", gotUrl, tc.wantUrl)
+			}
+
+			if gotSum != tc.wantSum {
+				t.Errorf("got %v, want %v", gotSum, tc.wantSum)
+			}
+		})
+	}
+}
+
+func TestDownloadVerified(tester *testing.T) {
+	defer test.Silencer()()
+
+	content := []byte("fake zip content")
+	h := sha256.Sum256(content)
+	goodSum := hex.EncodeToString(h[:])
+	badSum := hex.EncodeToString(make([]byte, 32))
+	zipHits := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tmpl.zip", "/sibling.zip":
+			zipHits++
+			_, _ = w.Write(content)
+		case "/sibling.zip.sha256":
+			_, _ = fmt.Fprintf(w, "%s  sibling.zip\n", goodSum)
+		case "/junk.zip", "/broken.zip":
+			zipHits++
+			_, _ = w.Write(content)
+		case "/junk.zip.sha256":
+			_, _ = fmt.Fprint(w, "<html>not a checksum</html>")
+		case "/broken.zip.sha256":
+			// Drop the connection, so the client gets a network error.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := server.Client()
+
+	var testCases = []struct {
+		name, url, sum string
+		wantErr        bool
+		wantHits       int
+	}{
+		{"flag", server.URL + "/tmpl.zip", goodSum, false, 1},
+		{"cached", server.URL + "/tmpl.zip", goodSum, false, 1},
+		{"fragment", server.URL + "/tmpl.zip#sha256=" + badSum, "", true, 2},
+		{"sibling", server.URL + "/sibling.zip", "", false, 2},
+		{"badFormat", server.URL + "/tmpl.zip", "1234", true, 2},
+		{"siblingNotHex", server.URL + "/junk.zip", "", false, 3},
+		{"siblingNetworkError", server.URL + "/broken.zip", "", false, 4},
+	}
+
+	dstDir := TmpDir + PS + "download-verified"
+	_ = os.MkdirAll(dstDir, DirMode)
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := DownloadVerified(tc.url, tc.sum, dstDir, client)
+
+			if tc.wantErr && err == nil {
+				t.Errorf("did not get expected err")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Errorf("got an unexpected err: %s", err)
+			}
+
+			if !tc.wantErr && !stdlib.PathExist(got) {
+				t.Errorf("download %q was not found", got)
+			}
+
+			if zipHits != tc.wantHits {
+				t.Errorf("got %v downloads, want %v", zipHits, tc.wantHits)
+			}
+		})
+	}
+
+	tester.Run("mismatchIsTyped", func(t *testing.T) {
+		_, err := DownloadVerified(server.URL+"/tmpl.zip", badSum, dstDir, client)
+
+		var ce *ChecksumError
+		if !errors.As(err, &ce) {
+			t.Fatalf("got %v, want a *ChecksumError", err)
+		}
+
+		if stdlib.PathExist(ce.File) {
+			t.Errorf("mismatched download %q was not deleted", ce.File)
+		}
+	})
+}
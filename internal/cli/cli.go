@@ -3,8 +3,17 @@ package cli
 import "os"
 
 const (
-	CmdConfig   = "config"
-	CmdManifest = "manifest"
-	DirMode     = 0774
-	PS          = string(os.PathSeparator)
+	CmdAlias        = "alias"
+	CmdAnswers      = "answers"
+	CmdCache        = "cache"
+	CmdConfig       = "config"
+	CmdInitTemplate = "init-template"
+	CmdInspect      = "inspect"
+	CmdManifest     = "manifest"
+	CmdMirror       = "mirror"
+	CmdTest         = "test"
+	CmdVersions     = "versions"
+	ConfigMode      = 0600 // Only the owner can read the config, it holds credentials.
+	DirMode         = 0774
+	PS              = string(os.PathSeparator)
 )
@@ -10,38 +10,99 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 type Config struct {
-	AnswersJson    *AnswersJson // data use for template processing
-	AnswersPath    string       // flag to get the path to a file containing values to variables to be parsed.
-	OutPath        string       // flag to set the location of the processed template output.
-	DataDir        string       // Directory to store app data.
-	DefaultVal     string       // Flag to set a default placeholder value when a placeholder is empty.
-	TmplPath       string       // flag to set the URL or local template path to a template.
-	Tmpl           string       // Path to template, this will be the cached path.
-	TmplJson       *TmplJson    // Data about the template such as placeholders, their descriptions, version, etc.
-	Branch         string       // flag to set the desired branch of the template to .
-	SubCmd         string       // sub-command to execute
-	TmplLocation   string       // Indicates local or remote location to downloaded
-	TmplType       string       // Flag to indicate the type of package for a template, such as a zip to Extract or a repository to Download.
-	CurrentVersion string       // Current semantic version of the application.
-	CommitHash     string       // Git commit has of the current version.
-	Help           bool         // flag to show the usage for all flags.
-	Path           string       // Path to configuration file.
-	Version        bool         // flag to show the current version
-	UsrOpts        *UserOptions // options that can configured by the user.
-	SubCmdConfig   struct {
+	Alias             string          // Name of the alias the template was given by.
+	AnswersJson       *AnswersJson    // data use for template processing
+	AnswersPath       string          // flag to get the path to a file containing values to variables to be parsed.
+	Batch             string          // flag to run the template for each row of a CSV or JSONL data set.
+	ContinueOnError   bool            // flag to keep going after a row of a batch fails.
+	Offline           bool            // flag to only use templates from the cache, also set by the Offline setting.
+	OutPath           string          // flag to set the location of the processed template output.
+	DataDir           string          // Directory to store app data.
+	DataFiles         DataFiles       // flag to give data files as name=path.
+	DefaultVal        string          // Flag to set a default placeholder value when a placeholder is empty.
+	TmplPath          string          // flag to set the URL or local template path to a template.
+	Tmpl              string          // Path to template, this will be the cached path.
+	TmplCommit        string          // Commit of a git template that was checked out.
+	TmplJson          *TmplJson       // Data about the template such as placeholders, their descriptions, version, etc.
+	TmplVersion       string          // flag to set a semantic version constraint, such as "^2.1", for a git template.
+	Branch            string          // flag to set the ref (branch, tag, full ref or commit) of a git template to use.
+	SubCmd            string          // sub-command to execute
+	TmplLocation      string          // Indicates local or remote location to downloaded
+	TmplType          string          // Flag to indicate the type of package for a template, such as a zip to Extract or a repository to Download.
+	CurrentVersion    string          // Current semantic version of the application.
+	CommitHash        string          // Git commit has of the current version.
+	Help              bool            // flag to show the usage for all flags.
+	Path              string          // Path to configuration file.
+	Policy            *Policy         // Effective allow/deny policy for template sources.
+	Pre               bool            // flag to include pre-release versions of a template.
+	RecordSecrets     bool            // flag to keep the passwords made, and the seed, in the record of a run.
+	RecurseSubmodules bool            // flag to also get the submodules of a git template.
+	RequireSignature  bool            // flag to fail when the signature of a template cannot be verified.
+	Seed              int64           // flag to seed the generated values, such as uuid, so a run makes the same ones.
+	SetFlags          map[string]bool // Names of the flags given on the command line.
+	Sha256            string          // flag to set the expected SHA-256 checksum of a zip template.
+	Version           bool            // flag to show the current version
+	UsrOpts           *UserOptions    // options that can configured by the user.
+	SubCmdAlias       struct {
+		Answers string // flag to set the answer file of an alias.
+		FlagSet *flag.FlagSet
+		Method  string // list, add or remove.
+		Name    string // alias to add or remove.
+		Ref     string // flag to pin the ref of an alias.
+		Source  string // template location of an alias.
+		Type    string // flag to set the template type of an alias.
+	}
+	SubCmdAnswers struct {
+		FlagSet *flag.FlagSet
+		Merge   bool   // flag to update an answer file that exists.
+		Method  string // init.
+		Out     string // flag to set the answer file to write.
+	}
+	SubCmdCache struct {
+		FlagSet   *flag.FlagSet
+		MaxSize   string // flag to set the size budget of the cache for prune.
+		Method    string // list, info, prune, clear or path.
+		OlderThan string // flag to set the age of entries to prune.
+		Source    string // template to show or clear.
+	}
+	SubCmdConfig struct {
 		FlagSet *flag.FlagSet
 		Key     string // config setting
 		Method  string // Method to call
 		Value   string // value to update config setting
 	}
+	SubCmdInitTemplate struct {
+		Dir     string // directory to make the template in.
+		FlagSet *flag.FlagSet
+		From    string // flag to start from an existing project.
+	}
+	SubCmdInspect struct {
+		FlagSet *flag.FlagSet
+		Format  string // flag to print as text or json.
+	}
 	SubCmdManifest struct {
 		FlagSet *flag.FlagSet
 		Path    string // path to generate a manifest for.
 	}
+	SubCmdMirror struct {
+		FlagSet *flag.FlagSet
+		File    string   // archive to export to or import from.
+		Method  string   // export or import.
+		Sources []string // templates to export, all in the cache when empty.
+	}
+	SubCmdTest struct {
+		Dir     string // template to test.
+		FlagSet *flag.FlagSet
+		Update  bool // flag to rewrite the expected output.
+	}
+	SubCmdVersions struct {
+		FlagSet *flag.FlagSet
+	}
 }
 
 // Setup All application configuration.
@@ -73,6 +134,22 @@ func (cfg *Config) Setup(appName, ps string, dirMode os.FileMode) error {
 		return e
 	}
 
+	policy, e2 := LoadPolicy(cfg.UsrOpts, SystemPolicyFile(appName))
+	if e2 != nil {
+		return e2
+	}
+	cfg.Policy = policy
+
+	// The -offline flag, when given, wins over the Offline setting.
+	if cfg.UsrOpts.Offline && !cfg.SetFlags["offline"] {
+		cfg.Offline = true
+	}
+
+	// An alias is replaced by the template location it stands for.
+	if e := cfg.resolveAlias(cfg.DataDir + ps + RegistryFile); e != nil {
+		return e
+	}
+
 	// Determine if the template is on the local file system or a remote server.
 	cfg.TmplLocation = cfg.getTmplLocation()
 
@@ -90,7 +167,7 @@ func (cfg *Config) initFile() error {
 		return nil
 	}
 
-	f, err1 := os.Create(cfg.Path)
+	f, err1 := os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, ConfigMode)
 	if err1 != nil {
 		return fmt.Errorf(Errors.CouldNotSaveConf, err1.Error())
 	}
@@ -118,7 +195,7 @@ func (cfg *Config) initFile() error {
 func (cfg *Config) saveUserSettings(mode os.FileMode) error {
 	data, err1 := json.Marshal(cfg.UsrOpts)
 
-	log.Dbugf(Messages.SaveData, data)
+	log.Dbugf(Messages.SaveData, Redact(string(data)))
 
 	if err1 != nil {
 		return fmt.Errorf(Errors.CouldNotEncodeConfig, err1.Error())
@@ -128,24 +205,17 @@ func (cfg *Config) saveUserSettings(mode os.FileMode) error {
 		return e
 	}
 
-	return nil
+	// WriteFile only sets the mode of a new file.
+	return os.Chmod(cfg.Path, mode)
 }
 
 // getTmplLocation Determine if the template is on the local file system or a remote server.
 func (cfg *Config) getTmplLocation() string {
-	tmplPath := cfg.TmplPath
-	regExpAbsolutePath := regexp.MustCompile(`^/([a-zA-Z._\-][a-zA-Z/._\-].*)?`)
-	regExpRelativePath := regexp.MustCompile(`^(\.\.|\.|~)(/[a-zA-Z/._\-].*)?`)
-	regExpWinDrive := regexp.MustCompile(`^[a-zA-Z]:\\[a-zA-Z/._\\-].*$`)
-	pathType := "remote"
-
-	if regExpAbsolutePath.MatchString(tmplPath) ||
-		regExpRelativePath.MatchString(tmplPath) ||
-		regExpWinDrive.MatchString(tmplPath) {
-		pathType = "local"
+	if IsRemoteLocation(cfg.TmplPath) {
+		return "remote"
 	}
 
-	return pathType
+	return "local"
 }
 
 // LoadUserSettings from a file, replacing the default built-in settings.
@@ -161,6 +231,19 @@ func (cfg *Config) LoadUserSettings(filename string) error {
 		return fmt.Errorf(Errors.CouldNotDecode, filename, er.Error())
 	}
 
+	for _, cred := range cfg.UsrOpts.Credentials {
+		if cred != nil {
+			cred.register()
+		}
+	}
+
+	// A config from before it held credentials may be readable by anyone.
+	if fi, e := os.Stat(filename); e == nil && len(cfg.UsrOpts.Credentials) > 0 && fi.Mode().Perm()&^ConfigMode != 0 {
+		if e2 := os.Chmod(filename, ConfigMode); e2 != nil {
+			log.Logf(Messages.ConfigNotPrivate, filename, e2.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -185,6 +268,14 @@ func LoadAnswers(filename string) (*AnswersJson, error) {
 type UserOptions struct {
 	ExcludeFileExtensions *[]string
 	CacheDir              string
+	TrustedKeys           []string               // ed25519 or minisign public keys (or paths to them) trusted to sign templates.
+	Credentials           map[string]*Credential // Credentials to access templates, keyed by host name.
+	AllowList             []string               // Patterns of template sources that are allowed, all are allowed when empty.
+	DenyList              []string               // Patterns of template sources that are never allowed.
+	GitBackend            string                 // Run the git binary (exec), or Git in-process (go); auto uses exec when git is installed.
+	SshCommand            string                 // Command git runs to reach SSH remotes, passed as GIT_SSH_COMMAND.
+	SshKey                string                 // Private key for SSH remotes, used when there is no SshCommand.
+	Offline               bool                   // Only use templates from the cache, never download or fetch them.
 }
 
 func UpdateUserSettings(cfg *Config, mode os.FileMode) error {
@@ -207,6 +298,10 @@ func UpdateUserSettings(cfg *Config, mode os.FileMode) error {
 
 // set the value of a user setting
 func (cfg *Config) set(key, val string) error {
+	if _, _, ok := aliasSetting(key); ok {
+		return cfg.setAlias(key, val)
+	}
+
 	switch key {
 	case "CacheDir":
 		log.Dbugf("setting CacheDir = %q", val)
@@ -217,6 +312,41 @@ func (cfg *Config) set(key, val string) error {
 		tmp := strings.Split(val, ",")
 		cfg.UsrOpts.ExcludeFileExtensions = &tmp
 		break
+	case "TrustedKeys":
+		log.Dbugf("setting trusted keys %q", val)
+		cfg.UsrOpts.TrustedKeys = strings.Split(val, ",")
+		break
+	case "AllowList":
+		log.Dbugf("setting allow-list %q", val)
+		cfg.UsrOpts.AllowList = splitList(val)
+		break
+	case "DenyList":
+		log.Dbugf("setting deny-list %q", val)
+		cfg.UsrOpts.DenyList = splitList(val)
+		break
+	case "GitBackend":
+		log.Dbugf("setting git backend %q", val)
+		if !regexp.MustCompile("^(auto|exec|go)?$").MatchString(val) {
+			return fmt.Errorf(Errors.BadGitBackend, val)
+		}
+		cfg.UsrOpts.GitBackend = val
+		break
+	case "SshCommand":
+		log.Dbugf("setting ssh command %q", val)
+		cfg.UsrOpts.SshCommand = val
+		break
+	case "SshKey":
+		log.Dbugf("setting ssh key %q", val)
+		cfg.UsrOpts.SshKey = val
+		break
+	case "Offline":
+		log.Dbugf("setting offline %q", val)
+		offline, e := strconv.ParseBool(val)
+		if e != nil {
+			return fmt.Errorf(Errors.InvalidBool, val, key)
+		}
+		cfg.UsrOpts.Offline = offline
+		break
 	default:
 		return fmt.Errorf("no %q setting found", key)
 	}
@@ -227,6 +357,10 @@ func (cfg *Config) set(key, val string) error {
 func (cfg *Config) get(key string) (interface{}, error) {
 	var val interface{}
 
+	if _, _, ok := aliasSetting(key); ok {
+		return cfg.getAlias(key)
+	}
+
 	switch key {
 	case "CacheDir":
 		val = cfg.UsrOpts.CacheDir
@@ -239,6 +373,35 @@ func (cfg *Config) get(key string) (interface{}, error) {
 		}
 		val = strings.Join(*cfg.UsrOpts.ExcludeFileExtensions, ",")
 		break
+	case "TrustedKeys":
+		val = strings.Join(cfg.UsrOpts.TrustedKeys, ",")
+		break
+	case "AllowList":
+		val = strings.Join(cfg.UsrOpts.AllowList, ",")
+		break
+	case "DenyList":
+		val = strings.Join(cfg.UsrOpts.DenyList, ",")
+		break
+	case "GitBackend":
+		val = cfg.UsrOpts.GitBackend
+		break
+	case "SshCommand":
+		val = cfg.UsrOpts.SshCommand
+		break
+	case "SshKey":
+		val = cfg.UsrOpts.SshKey
+		break
+	case "Offline":
+		val = cfg.UsrOpts.Offline
+		break
+	case "Policy":
+		// The effective policy, after the system-wide policy is applied.
+		data, e := json.MarshalIndent(cfg.Policy, "", "    ")
+		if e != nil {
+			return nil, e
+		}
+		val = string(data)
+		break
 	default:
 		return "", fmt.Errorf("no setting %v found", key)
 	}
@@ -246,6 +409,15 @@ func (cfg *Config) get(key string) (interface{}, error) {
 	return val, nil
 }
 
+// splitList Split a comma separated setting, an empty value clears the list.
+func splitList(val string) []string {
+	if strings.TrimSpace(val) == "" {
+		return nil
+	}
+
+	return strings.Split(val, ",")
+}
+
 // Validate parses command line flags into program options.
 func (cfg *Config) Validate() error {
 	if cfg.TmplPath == "" {
@@ -23,6 +23,9 @@ func TestGetTmplLocation(runner *testing.T) {
 		{"file", "remote", &Config{TmplPath: "file://example.com/repo1"}},
 		{"hiddenRelative", "local", &Config{TmplPath: ".m/example.com/repo1"}},
 		{"tildeRelative", "local", &Config{TmplPath: "~/repo1.git"}},
+		{"ssh", "remote", &Config{TmplPath: "ssh://git@example.com/repo1.git"}},
+		{"scpLike", "remote", &Config{TmplPath: "git@example.com:org/repo1.git"}},
+		{"remoteHelper", "remote", &Config{TmplPath: "ext::ssh example.com %S 'repo1.git'"}},
 	}
 
 	for _, tc := range fixtures {
@@ -73,15 +76,42 @@ func TestInitConfigFile(t *testing.T) {
 				t.Errorf("got %t; want %t", gotErr, tc.want)
 			}
 
-			_, err := os.Stat(tc.cfg.Path)
+			fi, err := os.Stat(tc.cfg.Path)
 
 			if err != nil {
 				t.Errorf("got %v; want %v", gotErr, tc.want)
+			} else if fi.Mode().Perm() != ConfigMode {
+				t.Errorf("got mode %v, want %v", fi.Mode().Perm(), os.FileMode(ConfigMode))
 			}
 		})
 	}
 }
 
+func TestConfigWithCredentialsIsPrivate(tester *testing.T) {
+	file := TmpDir + PS + "config-credentials.json"
+	_ = os.WriteFile(file, []byte(`{"Credentials": {"example.com": {"Token": "secret"}}}`), 0664)
+	_ = os.Chmod(file, 0664)
+
+	cfg := &Config{Path: file}
+	if e := cfg.LoadUserSettings(file); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if fi, _ := os.Stat(file); fi.Mode().Perm() != ConfigMode {
+		tester.Errorf("got mode %v after loading, want %v", fi.Mode().Perm(), os.FileMode(ConfigMode))
+	}
+
+	// Saved over a file anyone could read.
+	_ = os.Chmod(file, 0664)
+	if e := cfg.saveUserSettings(ConfigMode); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if fi, _ := os.Stat(file); fi.Mode().Perm() != ConfigMode {
+		tester.Errorf("got mode %v after saving, want %v", fi.Mode().Perm(), os.FileMode(ConfigMode))
+	}
+}
+
 func TestLoadAnswers(tester *testing.T) {
 	var fixtures = []struct {
 		name, file, want string
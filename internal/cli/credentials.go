@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const redacted = "****"
+
+// Credential Authentication for a template host, set per host in the user
+// config or read from the ~/.netrc file.
+type Credential struct {
+	Token    string            // Sent as a bearer token.
+	Username string            // Sent with Password as basic auth.
+	Password string            // Sent with Username as basic auth.
+	Headers  map[string]string // Custom headers, such as "Private-Token".
+}
+
+// AuthClient An HTTP client that applies the credentials of a host to every
+// request made to it.
+type AuthClient struct {
+	Client      Client
+	Credentials map[string]*Credential
+}
+
+var secrets = struct {
+	sync.Mutex
+	values []string
+}{}
+
+// NewAuthClient Wrap an HTTP client with per host credentials. An
+// *http.Client is copied, so its redirects can drop the credentials.
+func NewAuthClient(client Client, credentials map[string]*Credential) *AuthClient {
+	if hc, ok := client.(*http.Client); ok {
+		c := *hc
+		c.CheckRedirect = dropCredentials(credentials, hc.CheckRedirect)
+		client = &c
+	}
+
+	return &AuthClient{Client: client, Credentials: credentials}
+}
+
+func (c *AuthClient) Do(req *http.Request) (*http.Response, error) {
+	if cred := LookupCredential(req.URL.Hostname(), c.Credentials); cred != nil {
+		// Credentials are only sent encrypted.
+		if req.URL.Scheme == "https" {
+			cred.apply(req)
+		} else {
+			log.Logf(Messages.CredentialsNotHttps, RedactUrl(req.URL.String()))
+		}
+	}
+
+	return doRequest(c.Client, req)
+}
+
+func (c *AuthClient) Get(url string) (*http.Response, error) {
+	req, e := http.NewRequest(http.MethodGet, url, nil)
+	if e != nil {
+		return nil, e
+	}
+
+	return c.Do(req)
+}
+
+func (c *AuthClient) Head(url string) (*http.Response, error) {
+	req, e := http.NewRequest(http.MethodHead, url, nil)
+	if e != nil {
+		return nil, e
+	}
+
+	return c.Do(req)
+}
+
+// LookupCredential Return the credential for a host from the user config,
+// falling back to the ~/.netrc file, or nil when there is none.
+func LookupCredential(host string, credentials map[string]*Credential) *Credential {
+	if cred, ok := credentials[host]; ok && cred != nil {
+		cred.register()
+		return cred
+	}
+
+	cred := netrcCredential(netrcPath(), host)
+	if cred != nil {
+		cred.register()
+	}
+
+	return cred
+}
+
+// Redact Replace every known secret, and any password in a URL, with asterisks.
+func Redact(s string) string {
+	s = regexp.MustCompile(`(://[^/@:\s]+):[^/@\s]+@`).ReplaceAllString(s, "${1}:"+redacted+"@")
+
+	secrets.Lock()
+	defer secrets.Unlock()
+
+	for _, secret := range secrets.values {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
+
+// RegisterSecret Add a value that must be redacted from all output.
+func RegisterSecret(secret string) {
+	// Too short to be a secret, and would redact too much.
+	if len(secret) < 4 {
+		return
+	}
+
+	secrets.Lock()
+	defer secrets.Unlock()
+
+	for _, v := range secrets.values {
+		if v == secret {
+			return
+		}
+	}
+
+	secrets.values = append(secrets.values, secret)
+}
+
+// RedactUrl Remove the user info from a URL, for use in names and messages.
+func RedactUrl(rawUrl string) string {
+	u, e := url.Parse(rawUrl)
+	if e != nil || u.User == nil {
+		return rawUrl
+	}
+
+	u.User = nil
+
+	return u.String()
+}
+
+// apply Add the credential to an HTTP request.
+func (cred *Credential) apply(req *http.Request) {
+	switch {
+	case cred.Token != "":
+		req.Header.Set("Authorization", "Bearer "+cred.Token)
+	case cred.Username != "" || cred.Password != "":
+		req.SetBasicAuth(cred.Username, cred.Password)
+	}
+
+	for k, v := range cred.Headers {
+		req.Header.Set(k, v)
+	}
+}
+
+// drop Remove the credential from an HTTP request.
+func (cred *Credential) drop(req *http.Request) {
+	req.Header.Del("Authorization")
+
+	for k := range cred.Headers {
+		req.Header.Del(k)
+	}
+}
+
+// dropCredentials Check a redirect with next, after removing the credentials
+// of the first request when it goes to another host or is not HTTPS. The HTTP
+// client only removes Authorization and Cookie headers itself, so custom
+// headers, such as "Private-Token", would be sent to a CDN.
+func dropCredentials(credentials map[string]*Credential, next func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		first := via[0]
+		if req.URL.Host != first.URL.Host || req.URL.Scheme != "https" {
+			if cred := LookupCredential(first.URL.Hostname(), credentials); cred != nil {
+				cred.drop(req)
+			}
+			req.Header.Del("Authorization")
+		}
+
+		if next != nil {
+			return next(req, via)
+		}
+
+		// The same limit as the default policy of the HTTP client.
+		if len(via) >= 10 {
+			return errors.New(Errors.TooManyRedirects)
+		}
+
+		return nil
+	}
+}
+
+// register Mark all values of the credential as secrets.
+func (cred *Credential) register() {
+	RegisterSecret(cred.Token)
+	RegisterSecret(cred.Password)
+
+	for _, v := range cred.Headers {
+		RegisterSecret(v)
+	}
+}
+
+// netrcCredential Read the login and password for a host from a netrc file.
+func netrcCredential(file, host string) *Credential {
+	if file == "" || !stdlib.PathExist(file) {
+		return nil
+	}
+
+	f, e1 := os.Open(file)
+	if e1 != nil {
+		log.Dbugf(Messages.NetrcUnreadable, file, e1.Error())
+		return nil
+	}
+	defer f.Close()
+
+	var found, fallback *Credential
+	var current *Credential
+
+	scanner := bufio.NewScanner(f)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			if !scanner.Scan() {
+				break
+			}
+			current = nil
+			if scanner.Text() == host && found == nil {
+				found = &Credential{}
+				current = found
+			}
+		case "default":
+			current = nil
+			if fallback == nil {
+				fallback = &Credential{}
+				current = fallback
+			}
+		case "login":
+			if scanner.Scan() && current != nil {
+				current.Username = scanner.Text()
+			}
+		case "password":
+			if scanner.Scan() && current != nil {
+				current.Password = scanner.Text()
+			}
+		case "account", "macdef":
+			// Not used, skip the value.
+			scanner.Scan()
+		}
+	}
+
+	if found != nil {
+		return found
+	}
+
+	return fallback
+}
+
+// netrcPath Location of the netrc file, the NETRC environment variable
+// overrides the default in the home directory.
+func netrcPath() string {
+	if p, ok := os.LookupEnv("NETRC"); ok {
+		return p
+	}
+
+	home, e := stdlib.HomeDir()
+	if e != nil {
+		return ""
+	}
+
+	if runtime.GOOS == "windows" {
+		return home + PS + "_netrc"
+	}
+
+	return home + PS + ".netrc"
+}
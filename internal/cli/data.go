@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DataKey Name the data files are under in a template, such as
+// {{ .Data.regions }}.
+const DataKey = "Data"
+
+var reDataName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ReservedNames Names that placeholders cannot have, tmpltoapp sets them.
+var ReservedNames = []string{DataKey, MetaKey}
+
+// DataFiles Data files by name, from the -data flag, which can be given more
+// than once as name=path.
+type DataFiles map[string]string
+
+// String List the data files as name=path, for the flag package.
+func (df *DataFiles) String() string {
+	if df == nil {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(*df))
+	for name, path := range *df {
+		pairs = append(pairs, name+"="+path)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Set Add a data file given as name=path, for the flag package.
+func (df *DataFiles) Set(val string) error {
+	name, path, ok := strings.Cut(val, "=")
+	if !ok || path == "" {
+		return fmt.Errorf(Errors.BadDataFlag, val)
+	}
+
+	if !reDataName.MatchString(name) {
+		return fmt.Errorf(Errors.BadDataName, name)
+	}
+
+	if *df == nil {
+		*df = DataFiles{}
+	}
+	(*df)[name] = path
+
+	return nil
+}
+
+// CheckReservedNames Make sure no placeholder has a name tmpltoapp sets.
+func CheckReservedNames(tj *TmplJson) error {
+	for name := range tj.Placeholders {
+		if isReserved(name) {
+			return fmt.Errorf(Errors.ReservedPlaceholder, name)
+		}
+	}
+
+	return nil
+}
+
+// isReserved Check if tmpltoapp sets a name.
+func isReserved(name string) bool {
+	for _, r := range ReservedNames {
+		if r == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoadData Load the data files declared in template.json, which are relative
+// to the template, and the ones given, which replace those with the same name.
+func LoadData(tmplDir string, declared map[string]string, given DataFiles) (map[string]interface{}, error) {
+	files := map[string]string{}
+
+	for name, path := range declared {
+		if !reDataName.MatchString(name) {
+			return nil, fmt.Errorf(Errors.BadDataName, name)
+		}
+		// A template cannot read files outside itself, by path or by link.
+		file := filepath.Join(tmplDir, filepath.FromSlash(path))
+		if !filepath.IsLocal(filepath.FromSlash(path)) || !inDir(tmplDir, file) {
+			return nil, fmt.Errorf(Errors.DataOutsideTmpl, name, path)
+		}
+		files[name] = file
+	}
+
+	for name, path := range given {
+		files[name] = path
+	}
+
+	data := make(map[string]interface{}, len(files))
+	for name, file := range files {
+		v, e := ReadDataFile(file)
+		if e != nil {
+			return nil, e
+		}
+		data[name] = v
+	}
+
+	return data, nil
+}
+
+// inDir Check if a file is still in a directory once symbolic links are
+// followed. A file that does not exist is left for reading it to report.
+func inDir(dir, file string) bool {
+	realFile, e1 := filepath.EvalSymlinks(file)
+	if e1 != nil {
+		return true
+	}
+
+	realDir, e2 := filepath.EvalSymlinks(dir)
+	if e2 != nil {
+		return false
+	}
+
+	rel, e3 := filepath.Rel(realDir, realFile)
+
+	return e3 == nil && filepath.IsLocal(rel)
+}
+
+// ReadDataFile Read a JSON, YAML or CSV data file. A CSV file is a list of
+// rows keyed by its header.
+func ReadDataFile(file string) (interface{}, error) {
+	content, e1 := os.ReadFile(file)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotReadFile, file, e1.Error())
+	}
+
+	var v interface{}
+	var e2 error
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		e2 = json.Unmarshal(content, &v)
+	case ".yaml", ".yml":
+		e2 = yaml.Unmarshal(content, &v)
+	case ".csv":
+		v, e2 = readCsvRows(strings.NewReader(string(content)))
+	default:
+		return nil, fmt.Errorf(Errors.BadDataFile, file)
+	}
+
+	if e2 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, file, e2.Error())
+	}
+
+	return v, nil
+}
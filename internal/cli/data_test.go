@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDataFilesSet(tester *testing.T) {
+	var testCases = []struct {
+		name    string
+		val     string
+		wantErr bool
+	}{
+		{"ok", "regions=./regions.yaml", false},
+		{"noPath", "regions=", true},
+		{"noName", "./regions.yaml", true},
+		{"badName", "my-regions=./regions.yaml", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			df := DataFiles{}
+			err := df.Set(tc.val)
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got err %v, want an error %v", err, tc.wantErr)
+			}
+
+			if !tc.wantErr && df["regions"] != "./regions.yaml" {
+				t.Errorf("got %v, want regions=./regions.yaml", df.String())
+			}
+		})
+	}
+}
+
+func TestLoadData(tester *testing.T) {
+	dir := FixtureDir + PS + "data-01"
+
+	tj, err := ReadTemplateJson(dir + PS + TmplManifest)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	got, e2 := LoadData(dir, tj.Data, nil)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	regions := got["regions"].(map[string]interface{})["regions"].([]interface{})
+	if len(regions) != 2 || regions[0] != "us-east-1" {
+		tester.Errorf("got %v, want the regions from the YAML file", regions)
+	}
+
+	if got["versions"].(map[string]interface{})["go"] != "1.23" {
+		tester.Errorf("got %v, want the versions from the JSON file", got["versions"])
+	}
+
+	if owners := got["owners"].([]map[string]string); owners[0]["email"] != "web@example.com" {
+		tester.Errorf("got %v, want the rows of the CSV file", owners)
+	}
+
+	// A data file that is given replaces the one of the template.
+	given := DataFiles{"regions": FixtureDir + PS + "regions-02.yaml"}
+	got, _ = LoadData(dir, tj.Data, given)
+	regions = got["regions"].(map[string]interface{})["regions"].([]interface{})
+	if len(regions) != 1 || regions[0] != "ap-south-1" {
+		tester.Errorf("got %v, want the regions from the given file", regions)
+	}
+}
+
+func TestLoadDataErrors(tester *testing.T) {
+	var testCases = []struct {
+		name     string
+		declared map[string]string
+	}{
+		{"outside", map[string]string{"secrets": "../config-01.json"}},
+		{"badExt", map[string]string{"readme": "README.md"}},
+		{"notFound", map[string]string{"nope": "data/nope.json"}},
+		{"badName", map[string]string{"my-data": "data/versions.json"}},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadData(FixtureDir+PS+"data-01", tc.declared, nil); err == nil {
+				t.Errorf("got no error, want one")
+			}
+		})
+	}
+}
+
+func TestLoadDataLinkOutside(tester *testing.T) {
+	dir := TmpDir + PS + "data-link"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	outside, _ := filepath.Abs(FixtureDir + PS + "regions-02.yaml")
+	if e := os.Symlink(outside, dir+PS+"regions.yaml"); e != nil {
+		tester.Skipf("cannot make a symbolic link: %v", e)
+	}
+
+	if _, e := LoadData(dir, map[string]string{"regions": "regions.yaml"}, nil); e == nil {
+		tester.Errorf("got no error, want one for a link to a file outside the template")
+	}
+
+	// A link to a file of the template is fine.
+	_ = os.WriteFile(dir+PS+"versions.json", []byte(`{"go": "1.23"}`), 0644)
+	_ = os.Symlink("versions.json", dir+PS+"latest.json")
+	if _, e := LoadData(dir, map[string]string{"versions": "latest.json"}, nil); e != nil {
+		tester.Errorf("got an unexpected err: %s", e)
+	}
+}
+
+func TestParseWithData(tester *testing.T) {
+	dir := FixtureDir + PS + "data-01"
+	outDir := TmpDir + PS + "data-01-out"
+	_ = os.RemoveAll(outDir)
+	_ = os.MkdirAll(outDir, DirMode)
+
+	tj, _ := ReadTemplateJson(dir + PS + TmplManifest)
+	data, _ := LoadData(dir, tj.Data, nil)
+	values := map[string]interface{}{DataKey: data}
+
+	err := Parse(dir+PS+"README.md", outDir, tmplVars{"appName": "Web"}, values, nil)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	got, _ := os.ReadFile(outDir + PS + "README.md")
+	want := "# Web\n\nGo 1.23\n\n* us-east-1\n* eu-west-1\n\nOwned by web.\n"
+	if string(got) != want {
+		tester.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReservedPlaceholder(tester *testing.T) {
+	for _, name := range []string{DataKey, MetaKey} {
+		tj := &TmplJson{Placeholders: tmplVars{name: "Some value"}}
+
+		err := CheckReservedNames(tj)
+		if err == nil || !strings.Contains(err.Error(), name) {
+			tester.Errorf("got %v, want an error about %v", err, name)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// diffContext Number of unchanged lines shown around a change.
+	diffContext = 3
+	// diffMaxCells Largest table, changed lines of one file times those of the
+	// other, worth finding the smallest diff for, 4 MB; bigger changes are
+	// shown as replaced.
+	diffMaxCells = 1 << 20
+)
+
+// diffOp A line that is kept (' '), removed ('-') or added ('+').
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff Show the changes from a to b in the unified format, like
+// "diff -u". Returns an empty string when they are the same.
+func UnifiedDiff(fromName, toName string, a, b []byte) string {
+	if string(a) == string(b) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	// The line of each file that an op starts at.
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for k, op := range ops {
+		aPos[k+1], bPos[k+1] = aPos[k], bPos[k]
+		if op.kind != '+' {
+			aPos[k+1]++
+		}
+		if op.kind != '-' {
+			bPos[k+1]++
+		}
+	}
+
+	sb := &strings.Builder{}
+	_, _ = fmt.Fprintf(sb, "--- %v\n+++ %v\n", fromName, toName)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// Changes closer than twice the context go in the same hunk.
+		end := i
+		for k := i; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k
+			} else if k-end > 2*diffContext {
+				break
+			}
+		}
+
+		start := max(i-diffContext, 0)
+		stop := min(end+diffContext+1, len(ops))
+
+		_, _ = fmt.Fprintf(
+			sb,
+			"@@ -%v +%v @@\n",
+			hunkRange(aPos[start], aPos[stop]-aPos[start]),
+			hunkRange(bPos[start], bPos[stop]-bPos[start]),
+		)
+
+		for _, op := range ops[start:stop] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			if !strings.HasSuffix(op.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		i = stop
+	}
+
+	return sb.String()
+}
+
+// diffLines Find the fewest lines to remove from a and add to b, by the
+// longest common subsequence of their lines. Lines that are the same at the
+// start and end are kept as they are, so only the lines between them need a
+// table.
+func diffLines(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	for _, l := range a[:pre] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	ops = diffMiddle(ops, a[pre:len(a)-suf], b[pre:len(b)-suf])
+
+	for _, l := range a[len(a)-suf:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	return ops
+}
+
+// diffMiddle Append the ops that change a in to b, found with a table of the
+// longest common subsequence of every pair of their suffixes.
+func diffMiddle(ops []diffOp, a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	if n*m > diffMaxCells {
+		for _, l := range a {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+
+	// lcs(i, j) is at i*(m+1)+j.
+	w := m + 1
+	lcs := make([]int32, (n+1)*w)
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else {
+				lcs[i*w+j] = max(lcs[(i+1)*w+j], lcs[i*w+j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[(i+1)*w+j] >= lcs[i*w+j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+
+	return ops
+}
+
+// hunkRange Format the start line and number of lines of a hunk.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// No lines, so it is the line before.
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines Split text in to lines, each keeping its newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
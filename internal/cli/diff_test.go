@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(tester *testing.T) {
+	var testCases = []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"same", "a\nb\n", "a\nb\n", ""},
+		{
+			"changedLine",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			"--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{"added", "", "new\n", "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n"},
+		{"noNewline", "a\n", "a", "--- a\n+++ b\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n"},
+		{
+			"twoHunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			"one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			"--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got := UnifiedDiff("a", "b", []byte(tc.a), []byte(tc.b))
+
+			if got != tc.want {
+				t.Errorf("got\n%v\nwant\n%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffBig(runner *testing.T) {
+	lines := make([]string, 5000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d\n", i)
+	}
+	a := strings.Join(lines, "")
+
+	testCases := []struct {
+		name  string
+		b     string
+		wants []string
+	}{
+		// Only the lines around the change are compared.
+		{"oneLine", strings.Replace(a, "line 2500\n", "changed\n", 1), []string{"@@ -2498,7 +2498,7 @@\n", "-line 2500\n+changed\n"}},
+		// Too many changed lines for a table, so the whole file is replaced.
+		{"allLines", strings.ReplaceAll(a, "line", "row"), []string{"@@ -1,5000 +1,5000 @@\n-line 0\n"}},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			got := UnifiedDiff("a", "b", []byte(a), []byte(tc.b))
+
+			for _, want := range tc.wants {
+				if !strings.Contains(got, want) {
+					t.Errorf("got a diff without %q", want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	metaExt = ".meta.json"
+	partExt = ".part"
+)
+
+// requester A Client that can send a request with headers, such as an
+// *http.Client. Downloads are only revalidated and resumed with one.
+type requester interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DownloadSettings Control how templates are downloaded, change them before
+// calling Download.
+var DownloadSettings = struct {
+	Backoff  time.Duration // Wait before the first retry, doubled after each retry.
+	MaxSize  int64         // Maximum size of a download in bytes, 0 for no limit.
+	Progress io.Writer     // Print download progress here, nil for no progress.
+	Retries  int           // Number of times to retry a failed download.
+}{
+	Backoff: time.Second,
+	MaxSize: 500 << 20,
+	Retries: 3,
+}
+
+// downloadMeta Cache metadata used to revalidate and resume a download.
+type downloadMeta struct {
+	Url          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Size         int64  `json:"size"`
+	Complete     bool   `json:"complete"`
+}
+
+// retryableError A download failure that may succeed when tried again.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// DownloadName Name of the cache file for a download; the base name of the
+// URL with a hash of the URL, without user info, before the ".zip". For
+// example "0.3.0-<hash>.zip".
+func DownloadName(url string) string {
+	norm := normalizeSource(url)
+	base := norm[strings.LastIndex(norm, "/")+1:]
+	if i := strings.IndexAny(base, "?#"); i >= 0 {
+		base = base[:i]
+	}
+	ext := ""
+	if strings.EqualFold(filepath.Ext(base), ".zip") {
+		ext = filepath.Ext(base)
+	}
+
+	return cacheSafe(strings.TrimSuffix(base, ext)) + "-" + cacheHash(norm) + ext
+}
+
+// migrateDownload Move a download that was saved under the old name, the URL
+// with "https://" dropped and "/" replaced by "-", to its new name.
+func migrateDownload(url, dstDir, zipFile string) {
+	legacy := dstDir + PS + strings.ReplaceAll(strings.ReplaceAll(url, "https://", ""), "/", "-")
+	if legacy == zipFile || stdlib.PathExist(zipFile) || !stdlib.PathExist(legacy) {
+		return
+	}
+
+	if e := os.Rename(legacy, zipFile); e != nil {
+		log.Dbugf(e.Error())
+		return
+	}
+	_ = os.Rename(legacy+metaExt, zipFile+metaExt)
+	_ = os.Rename(legacy+partExt, zipFile+partExt)
+	// Extracted by an older version.
+	_ = os.RemoveAll(strings.ReplaceAll(legacy, ".zip", ""))
+
+	log.Infof(Messages.CacheMigrated, legacy, zipFile)
+}
+
+// downloadOnce Make a single (conditional or range) request for a download.
+func downloadOnce(url, zipFile string, client Client) error {
+	safeUrl := Redact(url)
+	meta := readDownloadMeta(zipFile)
+	if meta.Url != url {
+		meta = &downloadMeta{Url: url}
+	}
+
+	req, e1 := http.NewRequest(http.MethodGet, url, nil)
+	if e1 != nil {
+		return e1
+	}
+
+	validator := meta.ETag
+	if validator == "" {
+		validator = meta.LastModified
+	}
+
+	var offset int64
+	if meta.Complete && stdlib.PathExist(zipFile) {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	} else if fi, e := os.Stat(zipFile + partExt); e == nil && fi.Size() > 0 && validator != "" {
+		offset = fi.Size()
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
+
+	resp, e2 := doRequest(client, req)
+	if e2 != nil {
+		return retryable(e2)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		log.Infof(Messages.DownloadNotModified, safeUrl, zipFile)
+		return nil
+	case resp.StatusCode == http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			_ = os.Remove(zipFile + partExt)
+			return &retryableError{fmt.Errorf(Errors.DownloadBadRange, safeUrl, resp.Header.Get("Content-Range"))}
+		}
+		log.Infof(Messages.DownloadResume, safeUrl, offset)
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		_ = os.Remove(zipFile + partExt)
+		return &retryableError{fmt.Errorf(Errors.UnhandledHttpErr, resp.Status, resp.StatusCode)}
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		offset = 0
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return &retryableError{fmt.Errorf(Errors.UnhandledHttpErr, resp.Status, resp.StatusCode)}
+	default:
+		return fmt.Errorf(Errors.UnhandledHttpErr, resp.Status, resp.StatusCode)
+	}
+
+	total := int64(-1)
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+
+	if DownloadSettings.MaxSize > 0 && total > DownloadSettings.MaxSize {
+		return fmt.Errorf(Errors.DownloadTooBig, safeUrl, total, DownloadSettings.MaxSize)
+	}
+
+	meta.ETag = resp.Header.Get("ETag")
+	meta.LastModified = resp.Header.Get("Last-Modified")
+	meta.Size = total
+	meta.Complete = false
+	if e := writeDownloadMeta(zipFile, meta); e != nil {
+		return e
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	out, e3 := os.OpenFile(zipFile+partExt, flags, 0644)
+	if e3 != nil {
+		return e3
+	}
+
+	var body io.Reader = resp.Body
+	if DownloadSettings.MaxSize > 0 {
+		// Read 1 byte past the limit to detect a body that is too big.
+		body = io.LimitReader(resp.Body, DownloadSettings.MaxSize-offset+1)
+	}
+
+	var dst io.Writer = out
+	if DownloadSettings.Progress != nil {
+		dst = &progressWriter{w: out, out: DownloadSettings.Progress, name: safeUrl, done: offset, total: total}
+	}
+
+	n, e4 := io.Copy(dst, body)
+	if e := out.Close(); e != nil && e4 == nil {
+		e4 = e
+	}
+
+	if DownloadSettings.Progress != nil {
+		_, _ = fmt.Fprintln(DownloadSettings.Progress)
+	}
+
+	if DownloadSettings.MaxSize > 0 && offset+n > DownloadSettings.MaxSize {
+		_ = os.Remove(zipFile + partExt)
+		return fmt.Errorf(Errors.DownloadTooBig, safeUrl, offset+n, DownloadSettings.MaxSize)
+	}
+
+	if e4 != nil {
+		// Keep the partial download, so the next attempt can resume it.
+		return retryable(e4)
+	}
+
+	if total >= 0 && offset+n != total {
+		return &retryableError{fmt.Errorf(Errors.DownloadIncomplete, safeUrl, offset+n, total)}
+	}
+
+	if e := os.Rename(zipFile+partExt, zipFile); e != nil {
+		return e
+	}
+
+	meta.Size = offset + n
+	meta.Complete = true
+	if e := writeDownloadMeta(zipFile, meta); e != nil {
+		return e
+	}
+
+	log.Infof("downloading %v to %v\n", safeUrl, zipFile)
+
+	return nil
+}
+
+// doRequest Send a request with the client, or a plain GET or HEAD of its URL
+// when the client cannot send headers.
+func doRequest(client Client, req *http.Request) (*http.Response, error) {
+	if r, ok := client.(requester); ok {
+		return r.Do(req)
+	}
+
+	if req.Method == http.MethodHead {
+		return client.Head(req.URL.String())
+	}
+
+	return client.Get(req.URL.String())
+}
+
+// retryable Mark an error of a request as one to try again, unless trying
+// again cannot help; the request was canceled or the server is not trusted.
+func retryable(err error) error {
+	var certErr *tls.CertificateVerificationError
+	var hostErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var recordErr tls.RecordHeaderError
+	var unknownErr x509.UnknownAuthorityError
+
+	if errors.Is(err, context.Canceled) ||
+		errors.As(err, &certErr) ||
+		errors.As(err, &hostErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &unknownErr) {
+		return err
+	}
+
+	return &retryableError{err}
+}
+
+// readDownloadMeta Read the cache metadata of a download, returns empty
+// metadata when there is none.
+func readDownloadMeta(file string) *downloadMeta {
+	meta := &downloadMeta{}
+
+	content, e1 := ioutil.ReadFile(file + metaExt)
+	if e1 != nil {
+		return meta
+	}
+
+	if e := json.Unmarshal(content, meta); e != nil {
+		log.Dbugf(Errors.CouldNotDecode, file+metaExt, e.Error())
+		return &downloadMeta{}
+	}
+
+	return meta
+}
+
+// writeDownloadMeta Save the cache metadata of a download next to it.
+func writeDownloadMeta(file string, meta *downloadMeta) error {
+	data, e1 := json.Marshal(meta)
+	if e1 != nil {
+		return e1
+	}
+
+	return writeFileAtomic(file+metaExt, data, 0644)
+}
+
+// progressWriter Print the progress of a download as it is written.
+type progressWriter struct {
+	w           io.Writer
+	out         io.Writer
+	name        string
+	done, total int64
+	last        int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+
+	if p.total > 0 {
+		pct := p.done * 100 / p.total
+		if pct != p.last {
+			p.last = pct
+			_, _ = fmt.Fprintf(p.out, "\r"+Messages.DownloadProgress, p.name, pct, p.done, p.total)
+		}
+	} else if p.done-p.last >= 1<<20 {
+		p.last = p.done
+		_, _ = fmt.Fprintf(p.out, "\r"+Messages.DownloadProgressUnknown, p.name, p.done)
+	}
+
+	return n, err
+}
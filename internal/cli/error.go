@@ -2,45 +2,121 @@ package cli
 
 var Errors = struct {
 	AnswerFile404          string
+	AliasAnswers           string
+	AliasNoSource          string
+	AliasNotFound          string
+	AnswersExist           string
 	AppDataDir             string
+	BadAliasCmd            string
+	BadAliasName           string
+	BadAliasSetting        string
+	BadAnswersCmd          string
+	BadBatchFile           string
 	BadExcludeFileExt      string
+	BadFormat              string
+	BadGitBackend          string
 	BadTmplType            string
+	BadCacheCmd            string
+	BadDataFile            string
+	BadDataFlag            string
+	BadDataName            string
+	BadMirrorCmd           string
+	BadOutPathPattern      string
+	BatchEmpty             string
+	BatchFailed            string
+	BatchMissingAnswers    string
+	BatchOutPathTaken      string
+	BatchRowFailed         string
+	CacheEntryNotFound     string
+	CacheLock              string
+	CacheLockTimeout       string
+	CacheRemove            string
 	CannotDecodeAnswerFile string
 	CannotInitFileChecker  string
 	CannotReadAnswerFile   string
 	Checkout               string
+	ChecksumMismatch       string
 	Cloning                string
 	CouldNot               string
 	CouldNotCloseFile      string
 	CouldNotDecode         string
 	CouldNotEncodeConfig   string
+	CouldNotReadFile       string
 	CouldNotMakeCacheDir   string
 	CouldNotSaveConf       string
 	CouldNotWriteFile      string
+	DataOutsideTmpl        string
 	CurrentBranch          string
+	DownloadBadRange       string
+	DownloadIncomplete     string
+	DownloadTooBig         string
 	FatalHeader            string
 	FlagOrderErr           string
 	FileTooBig             string
+	FuncDictKey            string
+	FuncDictPairs          string
 	GettingAnswers         string
 	GettingCommitHash      string
 	GitCheckoutFailed      string
+	GitBackendUnsupported  string
 	GitFetchFailed         string
+	GitLfsNotInstalled     string
+	GitLsRemote            string
+	GitRefNotFound         string
+	GitRemoteNoUrl         string
+	GitSubmodules          string
+	GitBundle              string
+	GitVerifyFailed        string
+	GitWrongCommit         string
 	GitExitErrCode         string
 	GetLatestTag           string
 	GetRemoteTags          string
+	InvalidChecksum        string
+	InvalidConstraint      string
+	InvalidAge             string
+	InitTemplateNotEmpty   string
+	InvalidBool            string
 	InvalidNoArgs          string
+	InvalidPublicKey       string
+	InvalidSize            string
 	InvalidNoSubCmdArgs    string
 	InvalidTmplDir         string
+	LfsPointers            string
+	MirrorBadEntry         string
+	MirrorEmpty            string
+	MirrorNoBundle         string
+	MirrorRead             string
+	MirrorWrite            string
 	LocalOutPath           string
 	MissingTmplJson        string
 	NoGitTagFound          string
+	NoVersionMatch         string
+	NotCached              string
+	NotCachedZip           string
+	NoTemplateTests        string
+	NoPruneLimit           string
+	NoTrustedKeys          string
 	OutPathCollision       string
 	ParsingConfigArgs      string
+	PasswordTooShort       string
+	PathDenied             string
 	PathNotAllowed         string
+	RandIntRange           string
+	RandomSeed             string
 	RunGitFailed           string
+	ReservedPlaceholder    string
+	SignatureImpossible    string
+	SignatureInvalid       string
+	SignatureRequired      string
+	SubmoduleNotCached     string
+	SshKey                 string
+	TemplateTestsFailed    string
+	TestMissingAnswers     string
+	TestsNotIgnored        string
 	TmplManifest404        string
 	TmplOutput             string
 	TmplPath               string
+	TooManyRedirects       string
 	UnhandledHttpErr       string
 	encodingJson           string
 	savingManifest         string
@@ -48,45 +124,121 @@ var Errors = struct {
 	pathNotExist           string
 }{
 	AnswerFile404:          "could not find the answer file %q, please specify a path to a valid answer file that exist: given %q",
+	AliasAnswers:           "could not find the answer file %q of the alias %v",
+	AliasNoSource:          "the alias %v needs a template location",
+	AliasNotFound:          "there is no alias %q",
+	AnswersExist:           "%v exists, use -merge to update it",
 	AppDataDir:             "the following error occurred trying to get the app data directory: %q",
+	BadAliasCmd:            "unknown alias command %q, it must be list, add or remove",
+	BadAliasName:           "%q cannot be an alias, use letters, digits, \"-\" and \"_\"",
+	BadAliasSetting:        "no %q setting for an alias, it must be alias.<name>, alias.<name>.ref, alias.<name>.answers or alias.<name>.type",
+	BadAnswersCmd:          "unknown answers command %q, it must be init",
+	BadBatchFile:           "%v is not a data set, it must be a .csv or .jsonl file",
 	BadExcludeFileExt:      "invalid ExcludeFileExtensions, check format, for example: item1,item2,item3",
+	BadFormat:              "unknown format %q, it must be text or json",
+	BadGitBackend:          "%q is an invalid GitBackend, must be auto|exec|go",
 	BadTmplType:            "%q is an invalid value for flag tmplType, or it was not set, must be zip|git",
+	BadCacheCmd:            "%q is not a cache command, must be list|info|prune|clear|path",
+	BadDataFile:            "%v is not a data file, it must be a .json, .yaml, .yml or .csv file",
+	BadDataFlag:            "%q is not a data file, give it as -data name=path",
+	BadDataName:            "%q cannot be the name of a data file, use letters, digits and \"_\"",
+	BadMirrorCmd:           "unknown mirror command %q, it must be export or import",
+	BadOutPathPattern:      "could not fill in the out path pattern %q, %v",
+	BatchEmpty:             "there are no rows in %v",
+	BatchFailed:            "%d of %d rows failed",
+	BatchMissingAnswers:    "no value for %v",
+	BatchOutPathTaken:      "%v is made by row %d",
+	BatchRowFailed:         "row %d: %v",
+	CacheEntryNotFound:     "no template in the cache matches %q",
+	CacheLock:              "could not lock %v: %v",
+	CacheLockTimeout:       "gave up waiting for the lock %v after %v",
+	CacheRemove:            "could not remove %v from the cache: %v",
 	CannotDecodeAnswerFile: "could not decode JSON in answer file %q, because of: %s",
 	CannotInitFileChecker:  "cannot instantiate file extension checker: %v",
 	CannotReadAnswerFile:   "there was an error reading the answer file %q: %s",
 	Checkout:               "checkout failed for branch %q",
+	ChecksumMismatch:       "checksum mismatch for %q, expected sha256 %s but got %s",
 	Cloning:                "error cloning %v: %s",
 	CouldNot:               "could not %s",
 	CouldNotCloseFile:      "could not close file %v, %v",
 	CouldNotDecode:         "could not decode %q, error: %s",
 	CouldNotEncodeConfig:   "could not JSON encode user configuration settings, %v",
+	CouldNotReadFile:       "could not read file %v, reason: %v",
 	CouldNotMakeCacheDir:   "could not make cache directory, error: %s",
 	CouldNotSaveConf:       "could not save a config file, reason: %v",
 	CouldNotWriteFile:      "could not write file %v, reason: %v",
+	DataOutsideTmpl:        "the data file %v, %v, is not in the template",
 	CurrentBranch:          "failed to get current for %s",
+	DownloadBadRange:       "download of %v returned an unexpected content range %q",
+	DownloadIncomplete:     "download of %v is incomplete, got %d of %d bytes",
+	DownloadTooBig:         "download of %v is %d bytes, which is more than the limit of %d bytes",
 	FatalHeader:            "\nfatal error detected: ",
 	FlagOrderErr:           "flag %v MUST come before any non-flag arguments, a fix would be to move this flag to the left of other input arguments",
 	FileTooBig:             "template file too big to Parse, must be less thatn %v bytes",
+	FuncDictKey:            "dict keys must be strings, got %v",
+	FuncDictPairs:          "dict needs a value for each key, got %d arguments",
 	GettingAnswers:         "problem getting answers; error %q",
 	GettingCommitHash:      "error getting commit hash %v: %s",
 	GitCheckoutFailed:      "git checkout failed: %s",
+	GitBackendUnsupported:  "%v is not supported by the %q git backend",
 	GetLatestTag:           "failed to get latest tag from %v: %v",
 	GetRemoteTags:          "could not get remote tags, please check for a typo, it exist, and is readable: %v",
 	GitExitErrCode:         "git %v returned exit code %q",
 	GitFetchFailed:         "fetch failed on %s and %s; %s",
+	GitLfsNotInstalled:     "git-lfs is not installed",
+	GitLsRemote:            "could not list the refs of %v: %v",
+	GitRefNotFound:         "could not find ref %q in %v",
+	GitRemoteNoUrl:         "remote %v of %v has no URL",
+	GitSubmodules:          "could not update the submodules of %v: %v",
+	GitBundle:              "could not bundle %v: %v",
+	GitVerifyFailed:        "git signature verification failed for %v: %v",
+	GitWrongCommit:         "%v is at commit %v, expected %v",
+	InvalidChecksum:        "invalid sha256 checksum %q, it must be 64 hexadecimal characters",
+	InvalidConstraint:      "%q is not a valid version constraint: %v",
+	InvalidAge:             "%q is not a valid age, use days (30d) or a duration (12h)",
+	InitTemplateNotEmpty:   "%v is not empty, init-template only makes a new template",
+	InvalidBool:            "%q is not a valid value for %v, must be true or false",
 	InvalidNoArgs:          "invalid number of arguments passed to config sub-command, please try config -h for usage",
+	InvalidPublicKey:       "invalid public key %q: %s",
+	InvalidSize:            "%q is not a valid size, such as 500MB or 2GB",
 	InvalidNoSubCmdArgs:    "sub-command %v takes %v number of arguments, try \"%[1]s -h\" for usage",
 	InvalidTmplDir:         "invalid template directory %q",
+	LfsPointers:            "template %v has Git LFS pointer files instead of their content, install git-lfs and use the exec git backend: %v",
+	MirrorBadEntry:         "mirror %v has an entry %q that is not allowed",
+	MirrorEmpty:            "there are no templates in the cache to export",
+	MirrorNoBundle:         "no git bundle was made for %v",
+	MirrorRead:             "could not read the mirror %v: %v",
+	MirrorWrite:            "could not write the mirror %v: %v",
 	LocalOutPath:           "enter a local path to output the app",
 	MissingTmplJson:        "%s is a file that is required to be in the template, there was a problem reading %q; error %q",
 	NoGitTagFound:          "no tag found in %v",
+	NoVersionMatch:         "no version matches %q",
+	NotCached:              "%v at %q is not in the cache and offline mode is on; cached refs: %v",
+	NotCachedZip:           "%v is not in the cache and offline mode is on",
+	NoTemplateTests:        "there are no tests in %v, add a directory with an %v for each test case",
+	NoPruneLimit:           "cache prune needs -older-than or -max-size",
+	NoTrustedKeys:          "signature verification requires trusted public keys, add them with: config set TrustedKeys \"<key1>,<key2>\"",
 	OutPathCollision:       "-tmpl-path %q and -out-path %q cannot point to the same directory",
 	ParsingConfigArgs:      "error parsing config command args: %v",
-	PathNotAllowed:         "path/URL to template is not in the allow-list",
+	PasswordTooShort:       "password length %d is too short, it must be at least %d",
+	PathDenied:             "%q is not allowed by the deny-list pattern %q",
+	PathNotAllowed:         "path/URL to template %q is not in the allow-list",
+	RandIntRange:           "randInt needs a max greater than its min, got %d and %d",
+	RandomSeed:             "could not make a random seed: %v",
 	RunGitFailed:           "error running git %v: %v\n%s",
+	ReservedPlaceholder:    "%v cannot be a placeholder, tmpltoapp sets it",
+	SignatureImpossible:    "signature verification is not possible for template type %q",
+	SignatureInvalid:       "signature verification failed for %q: %s",
+	SignatureRequired:      "no signature found for %q, one is required by -require-signature",
+	SubmoduleNotCached:     "submodule %v is not in the cache, it cannot be cloned offline",
+	SshKey:                 "could not read ssh key %v: %v",
+	TemplateTestsFailed:    "%d of %d template tests failed",
+	TestMissingAnswers:     "answers.json has no value for %v",
+	TestsNotIgnored:        "add %v/ to %v, the tests are not part of what the template makes",
 	TmplManifest404:        "the required manifest %q file was not found",
 	TmplOutput:             "template has NOT been cloned locally",
 	TmplPath:               "please specify a path (or URL) to a template",
+	TooManyRedirects:       "stopped after 10 redirects",
 	UnhandledHttpErr:       "template Download aborted; I'm coded to NOT do anything when HTTP status is %q and status code is %d",
 	encodingJson:           "could not marshall actions in file %v, error: %v",
 	savingManifest:         "could not save file %v, error: %v",
@@ -0,0 +1,454 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// funcMap Functions for templates, shared by Parse and the manifest parser so
+// a template that parses for one parses for the other. Functions that take the
+// value to work on take it last, so they can end a pipeline, such as
+// {{ .appName | replace " " "-" | kebabCase }}.
+var funcMap = template.FuncMap{
+	// Case
+	"camelCase":     camelCase,
+	"kebabCase":     func(s string) string { return joinWords(s, "-", strings.ToLower) },
+	"pascalCase":    pascalCase,
+	"screamingCase": func(s string) string { return joinWords(s, "_", strings.ToUpper) },
+	"snakeCase":     func(s string) string { return joinWords(s, "_", strings.ToLower) },
+	"title":         title,
+	"toLower":       strings.ToLower,
+	"toUpper":       strings.ToUpper,
+	// Words
+	"pluralize":   pluralize,
+	"singularize": singularize,
+	// Strings
+	"indent":       indent,
+	"nindent":      func(n int, s string) string { return "\n" + indent(n, s) },
+	"quote":        func(v interface{}) string { return strconv.Quote(toString(v)) },
+	"regexReplace": regexReplace,
+	"replace":      func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"squote":       squote,
+	"trim":         strings.TrimSpace,
+	"trimPrefix":   func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix":   func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	// Defaults
+	"coalesce": coalesce,
+	"default":  defaultVal,
+	"ternary":  ternary,
+	// Lists and dictionaries
+	"dict":  dict,
+	"join":  join,
+	"list":  func(items ...interface{}) []interface{} { return items },
+	"split": func(sep, s string) []string { return strings.Split(s, sep) },
+	// Encoding
+	"b64dec":    b64dec,
+	"b64enc":    func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"sha256sum": sha256sum,
+	"toJson":    toJson,
+	"toToml":    toToml,
+	"toYaml":    toYaml,
+	// Versions
+	"semverCompare": semverCompare,
+}
+
+var (
+	// irregularPlurals Nouns that do not follow the rules, singular to plural.
+	irregularPlurals = map[string]string{
+		"child":  "children",
+		"foot":   "feet",
+		"goose":  "geese",
+		"man":    "men",
+		"mouse":  "mice",
+		"person": "people",
+		"tooth":  "teeth",
+		"woman":  "women",
+	}
+	// uncountables Nouns that are the same singular and plural.
+	uncountables = map[string]bool{
+		"data":        true,
+		"equipment":   true,
+		"fish":        true,
+		"information": true,
+		"news":        true,
+		"series":      true,
+		"sheep":       true,
+		"species":     true,
+	}
+)
+
+// camelCase Join the words of s, such as "app name", as "appName".
+func camelCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = capitalize(w)
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// capitalize Upper case the first letter of a word and lower case the rest.
+func capitalize(word string) string {
+	r := []rune(strings.ToLower(word))
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+
+	return string(r)
+}
+
+// coalesce Return the first value that is not empty.
+func coalesce(values ...interface{}) interface{} {
+	for _, v := range values {
+		if !isEmpty(v) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// defaultVal Return val, or def when val is empty, such as
+// {{ .port | default "8080" }}.
+func defaultVal(def, val interface{}) interface{} {
+	if isEmpty(val) {
+		return def
+	}
+
+	return val
+}
+
+// dict Make a map from key and value pairs.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf(Errors.FuncDictPairs, len(pairs))
+	}
+
+	d := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf(Errors.FuncDictKey, pairs[i])
+		}
+		d[key] = pairs[i+1]
+	}
+
+	return d, nil
+}
+
+// b64dec Decode base64.
+func b64dec(s string) (string, error) {
+	b, e := base64.StdEncoding.DecodeString(s)
+	if e != nil {
+		return "", e
+	}
+
+	return string(b), nil
+}
+
+// indent Put n spaces at the start of each line.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// isEmpty Check if a value is nil or the zero value of its type, or an empty
+// list or map.
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	case reflect.Struct:
+		return false
+	}
+
+	return rv.IsZero()
+}
+
+// join Join the items of a list with sep.
+func join(sep string, list interface{}) string {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice {
+		return toString(list)
+	}
+
+	items := make([]string, rv.Len())
+	for i := range items {
+		items[i] = toString(rv.Index(i).Interface())
+	}
+
+	return strings.Join(items, sep)
+}
+
+// joinWords Join the words of s with sep, each changed by f.
+func joinWords(s, sep string, f func(string) string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = f(w)
+	}
+
+	return strings.Join(words, sep)
+}
+
+// pascalCase Join the words of s, such as "app name", as "AppName".
+func pascalCase(s string) string {
+	return joinWords(s, "", capitalize)
+}
+
+// pluralize Make an English noun plural, such as "service" to "services".
+func pluralize(word string) string {
+	lower := strings.ToLower(word)
+
+	if uncountables[lower] {
+		return word
+	}
+
+	if p, ok := irregularPlurals[lower]; ok {
+		return matchCase(word, p)
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "s"),
+		strings.HasSuffix(lower, "x"),
+		strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"),
+		strings.HasSuffix(lower, "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(lower, "fe"):
+		return word[:len(word)-2] + "ves"
+	}
+
+	return word + "s"
+}
+
+// regexReplace Replace what matches expr in s with repl, which can refer to
+// groups such as ${1}.
+func regexReplace(expr, repl, s string) (string, error) {
+	re, e := regexp.Compile(expr)
+	if e != nil {
+		return "", e
+	}
+
+	return re.ReplaceAllString(s, repl), nil
+}
+
+// matchCase Upper case the first letter of word when like is.
+func matchCase(like, word string) string {
+	if r := []rune(like); len(r) > 0 && unicode.IsUpper(r[0]) {
+		return capitalize(word)
+	}
+
+	return word
+}
+
+// semverCompare Check if a version meets a constraint, such as ">=1.2 <2".
+func semverCompare(constraint, version string) (bool, error) {
+	c, e1 := semver.NewConstraint(constraint)
+	if e1 != nil {
+		return false, e1
+	}
+
+	v, e2 := semver.NewVersion(version)
+	if e2 != nil {
+		return false, e2
+	}
+
+	return c.Check(v), nil
+}
+
+// sha256sum Hash a string, in hex.
+func sha256sum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// singularize Make an English noun singular, such as "services" to "service".
+func singularize(word string) string {
+	lower := strings.ToLower(word)
+
+	if uncountables[lower] {
+		return word
+	}
+
+	for s, p := range irregularPlurals {
+		if p == lower {
+			return matchCase(word, s)
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "sses"),
+		strings.HasSuffix(lower, "xes"),
+		strings.HasSuffix(lower, "zes"),
+		strings.HasSuffix(lower, "ches"),
+		strings.HasSuffix(lower, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "ss"):
+		return word
+	case strings.HasSuffix(lower, "s"):
+		return word[:len(word)-1]
+	}
+
+	return word
+}
+
+// splitWords Split s in to words at spaces, punctuation and changes of case,
+// so "appName", "app_name" and "AppName" are all "app" and "Name".
+func splitWords(s string) []string {
+	runes := []rune(s)
+	words := []string{}
+	var cur []rune
+
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if len(cur) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			// "appName" and "v2Api", or the end of "HTTP" in "HTTPServer".
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				flush()
+			}
+		}
+
+		cur = append(cur, r)
+	}
+	flush()
+
+	return words
+}
+
+// squote Put a value in single quotes, as a shell would need it. A single
+// quote in the value ends the quotes, is escaped and starts them again:
+//
+//	it's becomes 'it'\''s'
+func squote(v interface{}) string {
+	return "'" + strings.ReplaceAll(toString(v), "'", `'\''`) + "'"
+}
+
+// ternary Return a when cond is true, otherwise b, such as
+// {{ ternary "yes" "no" .enabled }}. Answers are strings, so a string such as
+// "true" or "false" is read as a bool, any other value is true unless it is
+// empty.
+func ternary(a, b interface{}, cond interface{}) interface{} {
+	if truthy(cond) {
+		return a
+	}
+
+	return b
+}
+
+// title Upper case the first letter of each word, without the deprecated
+// strings.Title.
+func title(s string) string {
+	prev := ' '
+
+	return strings.Map(func(r rune) rune {
+		defer func() { prev = r }()
+		if isWordSeparator(prev) {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+// isWordSeparator Check if a rune is between words, the same as strings.Title.
+func isWordSeparator(r rune) bool {
+	if r <= 0x7F {
+		switch {
+		case '0' <= r && r <= '9', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '_':
+			return false
+		}
+		return true
+	}
+
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return false
+	}
+
+	return unicode.IsSpace(r)
+}
+
+// toJson Encode a value as JSON.
+func toJson(v interface{}) (string, error) {
+	b, e := json.Marshal(v)
+
+	return string(b), e
+}
+
+// toString Format a value as a string.
+func toString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+// toToml Encode a map or struct as TOML.
+func toToml(v interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+	if e := toml.NewEncoder(buf).Encode(v); e != nil {
+		return "", e
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// toYaml Encode a value as YAML.
+func toYaml(v interface{}) (string, error) {
+	b, e := yaml.Marshal(v)
+
+	return strings.TrimSuffix(string(b), "\n"), e
+}
+
+// truthy Check if a value counts as true, see ternary.
+func truthy(v interface{}) bool {
+	if s, ok := v.(string); ok {
+		if b, e := strconv.ParseBool(strings.TrimSpace(s)); e == nil {
+			return b
+		}
+	}
+
+	return !isEmpty(v)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func execFuncs(text string, data interface{}) (string, error) {
+	t, e1 := template.New("test").Funcs(funcMap).Parse(text)
+	if e1 != nil {
+		return "", e1
+	}
+
+	sb := &strings.Builder{}
+	e2 := t.Execute(sb, data)
+
+	return sb.String(), e2
+}
+
+func TestFuncMap(runner *testing.T) {
+	data := map[string]interface{}{
+		"empty":   "",
+		"items":   []string{"a", "b", "c"},
+		"name":    "my app_name",
+		"on":      true,
+		"onText":  "true",
+		"offText": "false",
+		"port":    0,
+		"regions": map[string]interface{}{"us": "east"},
+	}
+
+	testCases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"camelCase", `{{ camelCase "HTTPServer name" }}`, "httpServerName"},
+		{"pascalCase", `{{ .name | pascalCase }}`, "MyAppName"},
+		{"snakeCase", `{{ snakeCase "myAppName" }}`, "my_app_name"},
+		{"kebabCase", `{{ kebabCase "MyApp v2Api" }}`, "my-app-v2-api"},
+		{"screamingCase", `{{ screamingCase "my-app" }}`, "MY_APP"},
+		{"title", `{{ title "hello wide world" }}`, "Hello Wide World"},
+		{"toLower", `{{ toLower "ABC" }}`, "abc"},
+		{"toUpper", `{{ toUpper "abc" }}`, "ABC"},
+		{"pluralize", `{{ pluralize "service" }} {{ pluralize "policy" }} {{ pluralize "box" }} {{ pluralize "Person" }} {{ pluralize "sheep" }}`, "services policies boxes People sheep"},
+		{"singularize", `{{ singularize "services" }} {{ singularize "policies" }} {{ singularize "boxes" }} {{ singularize "children" }} {{ singularize "class" }}`, "service policy box child class"},
+		{"trim", `{{ trim "  a  " }}|{{ trimPrefix "v" "v1.0" }}|{{ trimSuffix ".go" "main.go" }}`, "a|1.0|main"},
+		{"replace", `{{ .name | replace " " "-" }}`, "my-app_name"},
+		{"regexReplace", `{{ regexReplace "([a-z]+)-([0-9]+)" "${2}-${1}" "app-12" }}`, "12-app"},
+		{"indent", `{{ indent 2 "a\nb" }}`, "  a\n  b"},
+		{"nindent", `x:{{ nindent 2 "a" }}`, "x:\n  a"},
+		{"quote", `{{ quote "a\"b" }} {{ squote 1 }}`, `"a\"b" '1'`},
+		{"default", `{{ .empty | default "x" }} {{ .port | default 8080 }} {{ default "x" "y" }}`, "x 8080 y"},
+		{"coalesce", `{{ coalesce .empty .port "z" }}`, "z"},
+		{"ternary", `{{ ternary "yes" "no" .on }}`, "yes"},
+		{"ternaryAnswers", `{{ ternary "yes" "no" .onText }} {{ ternary "yes" "no" .offText }} {{ ternary "yes" "no" .name }} {{ ternary "yes" "no" .empty }} {{ ternary "yes" "no" .port }}`, "yes no yes no no"},
+		{"squoteEscapes", `{{ squote "it's" }}`, `'it'\''s'`},
+		{"join", `{{ join ", " .items }}`, "a, b, c"},
+		{"split", `{{ range split "," "a,b" }}[{{ . }}]{{ end }}`, "[a][b]"},
+		{"list", `{{ join "-" (list 1 "b" true) }}`, "1-b-true"},
+		{"dict", `{{ $d := dict "a" 1 "b" "two" }}{{ $d.b }}`, "two"},
+		{"toJson", `{{ dict "a" 1 | toJson }}`, `{"a":1}`},
+		{"toYaml", `{{ .regions | toYaml }}`, "us: east"},
+		{"toToml", `{{ .regions | toToml }}`, `us = "east"`},
+		{"base64", `{{ b64enc "hi" }} {{ b64enc "hi" | b64dec }}`, "aGk= hi"},
+		{"sha256sum", `{{ sha256sum "abc" }}`, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"semverCompare", `{{ semverCompare ">=1.2.0" "1.10.0" }} {{ semverCompare "^2" "1.0.0" }}`, "true false"},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			got, err := execFuncs(tc.text, data)
+
+			if err != nil {
+				t.Fatalf("want nil, got: %q", err.Error())
+			}
+
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFuncMapErrors(runner *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+	}{
+		{"dictOddArgs", `{{ dict "a" }}`},
+		{"dictKeyNotString", `{{ dict 1 2 }}`},
+		{"badRegex", `{{ regexReplace "(" "" "a" }}`},
+		{"badBase64", `{{ b64dec "!" }}`},
+		{"badConstraint", `{{ semverCompare "~>" "1.0.0" }}`},
+		{"badVersion", `{{ semverCompare "1.0.0" "one" }}`},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			_, err := execFuncs(tc.text, nil)
+
+			if err == nil {
+				t.Errorf("want an error, got nil")
+			}
+		})
+	}
+}
+
+func TestSplitWords(runner *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"appName", "app Name"},
+		{"AppName", "App Name"},
+		{"app_name", "app name"},
+		{"app-name v2", "app name v2"},
+		{"HTTPServer", "HTTP Server"},
+		{"v2Api", "v2 Api"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.in, func(t *testing.T) {
+			got := strings.Join(splitWords(tc.in), " ")
+
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
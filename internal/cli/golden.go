@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TestsDir Directory, in a template, with a directory for each test case.
+	TestsDir = "tests"
+	// testAnswersFile Answers a test case renders the template with.
+	testAnswersFile = "answers.json"
+	// testExpectedDir What a test case expects the template to make.
+	testExpectedDir = "expected"
+)
+
+// testTime Time of every test case, so the expected output does not change
+// from day to day.
+var testTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// TestResult The outcome of a template test case.
+type TestResult struct {
+	Name    string
+	Diff    string // Unified diff of the expected and actual output.
+	Err     error  // Why the case could not be run.
+	Updated bool   // The expected output was rewritten.
+}
+
+// Passed Check if the template made what the case expected.
+func (tr *TestResult) Passed() bool {
+	return tr.Err == nil && tr.Diff == ""
+}
+
+// RunTemplateTests Render a template with the answers of each of its test
+// cases, tests/<case>/answers.json, and compare it to tests/<case>/expected.
+// With update, the expected output is replaced instead.
+func RunTemplateTests(tmplDir string, fec *stdlib.FileExtChecker, update bool) ([]*TestResult, error) {
+	tj, e1 := ReadTemplateJson(tmplDir + PS + TmplManifest)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	// The tests must not end up in what the template makes.
+	ignore, e2 := ReadIgnoreFile(tmplDir)
+	if e2 != nil {
+		return nil, e2
+	}
+	if !Ignored(ignore, TestsDir, true) {
+		return nil, fmt.Errorf(Errors.TestsNotIgnored, TestsDir, IgnoreFile)
+	}
+
+	data, e3 := LoadData(tmplDir, tj.Data, nil)
+	if e3 != nil {
+		return nil, e3
+	}
+	// The location of the template depends on where it is tested from.
+	meta := &Config{TmplPath: filepath.Base(tmplDir), TmplType: "dir", TmplJson: tj}
+
+	cases, e4 := findTestCases(tmplDir + PS + TestsDir)
+	if e4 != nil {
+		return nil, e4
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf(Errors.NoTemplateTests, tmplDir+PS+TestsDir, testAnswersFile)
+	}
+
+	results := make([]*TestResult, 0, len(cases))
+	for _, name := range cases {
+		values := map[string]interface{}{
+			DataKey: data,
+			MetaKey: NewMeta(meta, name, testTime),
+		}
+		tr := &TestResult{Name: name}
+		tr.Diff, tr.Err = runTemplateTest(tmplDir, tmplDir+PS+TestsDir+PS+name, tj, fec, values, update)
+		tr.Updated = update && tr.Err == nil
+		results = append(results, tr)
+	}
+
+	return results, nil
+}
+
+// DiffDirs Compare the files in two directories, returning a unified diff of
+// each file that is different or only in one of them.
+func DiffDirs(expectedDir, actualDir string) (string, error) {
+	expected, e1 := listFiles(expectedDir)
+	if e1 != nil {
+		return "", e1
+	}
+
+	actual, e2 := listFiles(actualDir)
+	if e2 != nil {
+		return "", e2
+	}
+
+	all := make(map[string]bool, len(expected)+len(actual))
+	for f := range expected {
+		all[f] = true
+	}
+	for f := range actual {
+		all[f] = true
+	}
+
+	files := make([]string, 0, len(all))
+	for f := range all {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	sb := &strings.Builder{}
+	for _, f := range files {
+		var a, b []byte
+		from, to := "/dev/null", "/dev/null"
+
+		if expected[f] {
+			from = testExpectedDir + "/" + f
+			content, e := os.ReadFile(filepath.Join(expectedDir, f))
+			if e != nil {
+				return "", e
+			}
+			a = content
+		}
+
+		if actual[f] {
+			to = "actual/" + f
+			content, e := os.ReadFile(filepath.Join(actualDir, f))
+			if e != nil {
+				return "", e
+			}
+			b = content
+		}
+
+		if expected[f] && actual[f] && bytes.Equal(a, b) {
+			continue
+		}
+
+		if d := UnifiedDiff(from, to, a, b); d != "" {
+			sb.WriteString(d)
+		} else {
+			// An empty file on one side only.
+			_, _ = fmt.Fprintf(sb, "--- %v\n+++ %v\n", from, to)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// findTestCases Return the names of the directories with an answer file.
+func findTestCases(testsDir string) ([]string, error) {
+	files, e1 := os.ReadDir(testsDir)
+	if os.IsNotExist(e1) {
+		return nil, nil
+	}
+	if e1 != nil {
+		return nil, e1
+	}
+
+	cases := []string{}
+	for _, f := range files {
+		if f.IsDir() && stdlib.PathExist(testsDir+PS+f.Name()+PS+testAnswersFile) {
+			cases = append(cases, f.Name())
+		}
+	}
+
+	return cases, nil
+}
+
+// listFiles Return the files in a directory, relative to it with "/"
+// separators. There are none when it does not exist.
+func listFiles(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+
+	if !stdlib.DirExist(dir) {
+		return files, nil
+	}
+
+	e1 := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files[filepath.ToSlash(rel)] = true
+		}
+
+		return nil
+	})
+
+	return files, e1
+}
+
+// runTemplateTest Render a template for a test case, returning how the output
+// is different from what is expected.
+func runTemplateTest(tmplDir, caseDir string, tj *TmplJson, fec *stdlib.FileExtChecker, values map[string]interface{}, update bool) (string, error) {
+	aj, e1 := LoadAnswers(caseDir + PS + testAnswersFile)
+	if e1 != nil {
+		return "", e1
+	}
+
+	missing := []string{}
+	for name := range tj.Placeholders {
+		if _, ok := aj.Placeholders[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return "", fmt.Errorf(Errors.TestMissingAnswers, strings.Join(missing, ", "))
+	}
+
+	outDir, e2 := os.MkdirTemp("", "tmpltoapp-test-")
+	if e2 != nil {
+		return "", e2
+	}
+	defer func() {
+		_ = os.RemoveAll(outDir)
+	}()
+
+	// A case has the same generated values every time, from its seed or 0.
+	var seed int64
+	if aj.Seed != nil {
+		seed = *aj.Seed
+	}
+
+	if e := ParseDir(tmplDir, outDir, aj.Placeholders, fec, tj.Excludes, tj.Skip, values, NewGenerator(seed, aj.Generated)); e != nil {
+		return "", e
+	}
+
+	expectedDir := caseDir + PS + testExpectedDir
+
+	if update {
+		if e := os.RemoveAll(expectedDir); e != nil {
+			return "", e
+		}
+		return "", copyTree(outDir, expectedDir)
+	}
+
+	return DiffDirs(expectedDir, outDir)
+}
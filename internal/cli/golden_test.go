@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"github.com/kohirens/stdlib"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunTemplateTests(tester *testing.T) {
+	dir := TmpDir + PS + "golden-01"
+	_ = os.RemoveAll(dir)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{".empty", "exe", "gif", "jpg", "mp3", "pdf", "png", "tiff", "wmv"}, &[]string{})
+
+	if _, e := InitTemplate(dir, "", fec); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	// Nothing is expected yet, so everything made is a difference.
+	got, err := RunTemplateTests(dir, fec, false)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if len(got) != 1 || got[0].Passed() || !strings.Contains(got[0].Diff, "+++ actual/README.md") {
+		tester.Fatalf("got %+v, want the default case to fail with a diff", got[0])
+	}
+
+	got, err = RunTemplateTests(dir, fec, true)
+	if err != nil || !got[0].Updated {
+		tester.Fatalf("got %+v, %v; want the expected output updated", got[0], err)
+	}
+
+	if !stdlib.PathExist(dir + PS + TestsDir + PS + "default" + PS + testExpectedDir + PS + "example.txt") {
+		tester.Fatalf("got no expected output, want it saved")
+	}
+
+	got, _ = RunTemplateTests(dir, fec, false)
+	if !got[0].Passed() {
+		tester.Errorf("got %+v, want the default case to pass", got[0])
+	}
+
+	_ = os.WriteFile(dir+PS+"example.txt", []byte("Goodbye from {{.appName}}!\n"), 0644)
+
+	got, _ = RunTemplateTests(dir, fec, false)
+	want := "@@ -1 +1 @@\n-Hello from Example App!\n+Goodbye from Example App!\n"
+	if got[0].Passed() || !strings.Contains(got[0].Diff, want) {
+		tester.Errorf("got %v, want it to contain %v", got[0].Diff, want)
+	}
+}
+
+func TestRunTemplateTestsGenerated(tester *testing.T) {
+	dir := TmpDir + PS + "golden-02"
+	_ = os.RemoveAll(dir)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{".empty", "exe", "gif", "jpg", "mp3", "pdf", "png", "tiff", "wmv"}, &[]string{})
+
+	if _, e := InitTemplate(dir, "", fec); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+	_ = os.WriteFile(dir+PS+"example.txt", []byte("{{uuid}} {{password 12}}\n"), 0644)
+
+	if _, e := RunTemplateTests(dir, fec, true); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	// The generated values are the same every run.
+	got, _ := RunTemplateTests(dir, fec, false)
+	if !got[0].Passed() {
+		tester.Errorf("got %+v, want the default case to pass", got[0])
+	}
+
+	// A case can give its own seed.
+	_ = os.WriteFile(dir+PS+TestAnswers, []byte(`{"seed": 1, "placeholders": {"appName": "Example App"}}`), 0644)
+
+	got, _ = RunTemplateTests(dir, fec, false)
+	if got[0].Err != nil || got[0].Diff == "" {
+		tester.Errorf("got %+v, want another seed to make other values", got[0])
+	}
+}
+
+func TestRunTemplateTestsErrors(tester *testing.T) {
+	dir := TmpDir + PS + "golden-02"
+	_ = os.RemoveAll(dir)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{".empty", "exe", "gif", "jpg", "mp3", "pdf", "png", "tiff", "wmv"}, &[]string{})
+
+	if _, e := InitTemplate(dir, "", fec); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	_ = os.WriteFile(dir+PS+TestAnswers, []byte(`{"placeholders": {}}`), 0644)
+
+	got, err := RunTemplateTests(dir, fec, false)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if got[0].Err == nil || !strings.Contains(got[0].Err.Error(), "appName") {
+		tester.Errorf("got %v, want an error about the missing answer to appName", got[0].Err)
+	}
+
+	_ = os.Remove(dir + PS + IgnoreFile)
+
+	if _, e := RunTemplateTests(dir, fec, false); e == nil {
+		tester.Errorf("got no error, want one when tests/ is not ignored")
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+)
+
+// IgnoreFile Name of the file, in a template, that lists files and
+// directories that are not part of what the template makes, such as its own
+// docs and tests. It is never copied either.
+const IgnoreFile = ".tmpltoappignore"
+
+// ReadIgnoreFile Read the patterns in the ignore file of a template, there
+// are none when it does not have one. Blank lines and lines that start with
+// "#" are skipped.
+func ReadIgnoreFile(tmplDir string) ([]string, error) {
+	f, e1 := os.Open(tmplDir + PS + IgnoreFile)
+	if os.IsNotExist(e1) {
+		return nil, nil
+	}
+	if e1 != nil {
+		return nil, e1
+	}
+	defer f.Close()
+
+	patterns := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+
+	return patterns, scanner.Err()
+}
+
+// Ignored Check if a path, relative to the template with "/" separators,
+// matches an ignore pattern. Like .gitignore, a pattern without a "/" matches
+// the name at any depth, one with a "/" matches from the root of the template,
+// and one that ends with "/" only matches directories.
+func Ignored(patterns []string, rel string, isDir bool) bool {
+	for _, p := range patterns {
+		dirOnly := strings.HasSuffix(p, "/")
+		p = strings.TrimSuffix(p, "/")
+
+		if dirOnly && !isDir {
+			continue
+		}
+
+		name := path.Base(rel)
+		if strings.Contains(p, "/") {
+			name = rel
+			p = strings.TrimPrefix(p, "/")
+		}
+
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"os"
+	"testing"
+)
+
+func TestReadIgnoreFile(tester *testing.T) {
+	dir := TmpDir + PS + "read-ignore-file"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	got, err := ReadIgnoreFile(dir)
+	if err != nil || got != nil {
+		tester.Fatalf("got %v, %v; want no patterns when there is no ignore file", got, err)
+	}
+
+	_ = os.WriteFile(dir+PS+IgnoreFile, []byte("# docs\nTEMPLATE.md\n\n  testdata/  \n"), 0644)
+
+	got, err = ReadIgnoreFile(dir)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if len(got) != 2 || got[0] != "TEMPLATE.md" || got[1] != "testdata/" {
+		tester.Errorf("got %q, want [TEMPLATE.md testdata/]", got)
+	}
+}
+
+func TestIgnored(tester *testing.T) {
+	patterns := []string{"TEMPLATE.md", "testdata/", "/docs/*.draft", "*.bak"}
+
+	var testCases = []struct {
+		name  string
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"name", "TEMPLATE.md", false, true},
+		{"nameInSubDir", "sub/TEMPLATE.md", false, true},
+		{"dir", "testdata", true, true},
+		{"dirOnly", "testdata", false, false},
+		{"rooted", "docs/a.draft", false, true},
+		{"rootedNotDeeper", "sub/docs/a.draft", false, false},
+		{"glob", "src/main.go.bak", false, true},
+		{"notIgnored", "README.md", false, false},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			if got := Ignored(patterns, tc.rel, tc.isDir); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
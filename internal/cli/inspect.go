@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+)
+
+// Contract What a template needs and does, from its template.json, shown
+// before it is used.
+type Contract struct {
+	Commit       string         `json:"commit,omitempty"`
+	Description  string         `json:"description,omitempty"`
+	Excludes     []string       `json:"excludes"`
+	Files        int            `json:"files"`
+	Placeholders []*Placeholder `json:"placeholders"`
+	Ref          string         `json:"ref,omitempty"`
+	Skip         []string       `json:"skip"`
+	Source       string         `json:"source"`
+	Validation   []*Rule        `json:"validation"`
+	Version      string         `json:"version"`
+}
+
+// Placeholder A value a template asks for.
+type Placeholder struct {
+	Name   string   `json:"name"`
+	Prompt string   `json:"prompt"`
+	Rules  []string `json:"rules,omitempty"` // Validation rules of the placeholder.
+}
+
+// Rule A validation rule for placeholder values.
+type Rule struct {
+	Expression string   `json:"expression,omitempty"`
+	Fields     []string `json:"fields"`
+	Message    string   `json:"message,omitempty"`
+	Rule       string   `json:"rule"`
+}
+
+// Inspect Make the contract of a template in a directory, with its manifest.
+func Inspect(tmplDir string, tj *TmplJson) (*Contract, error) {
+	files, e1 := CountFiles(tmplDir)
+	if e1 != nil {
+		return nil, e1
+	}
+
+	c := &Contract{
+		Description:  tj.Description,
+		Excludes:     nonNil(tj.Excludes),
+		Files:        files,
+		Placeholders: []*Placeholder{},
+		Skip:         nonNil(tj.Skip),
+		Validation:   []*Rule{},
+		Version:      tj.Version,
+	}
+
+	for _, v := range tj.Validation {
+		c.Validation = append(c.Validation, &Rule{Expression: v.expression, Fields: v.fields, Message: v.message, Rule: v.rule})
+	}
+
+	for name, prompt := range tj.Placeholders {
+		p := &Placeholder{Name: name, Prompt: prompt}
+		for _, v := range tj.Validation {
+			for _, field := range v.fields {
+				if field == name {
+					p.Rules = append(p.Rules, v.rule)
+				}
+			}
+		}
+		c.Placeholders = append(c.Placeholders, p)
+	}
+
+	sort.Slice(c.Placeholders, func(i, j int) bool {
+		return c.Placeholders[i].Name < c.Placeholders[j].Name
+	})
+
+	return c, nil
+}
+
+// CountFiles Count the files of a template, without the template.json and
+// anything in .git.
+func CountFiles(tmplDir string) (int, error) {
+	n := 0
+
+	e1 := filepath.WalkDir(tmplDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == gitDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Name() != TmplManifest {
+			n++
+		}
+
+		return nil
+	})
+
+	return n, e1
+}
+
+// nonNil Return an empty list for nil, so it is [] in JSON.
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+
+	return list
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInspect(tester *testing.T) {
+	dir := FixtureDir + PS + "inspect-01"
+
+	tj, err := ReadTemplateJson(dir + PS + TmplManifest)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	got, e2 := Inspect(dir, tj)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if got.Version != "1.2.0" || got.Description != "A web app in Go" || got.Files != 2 {
+		tester.Errorf("got %v %q %v files, want 1.2.0 \"A web app in Go\" 2 files", got.Version, got.Description, got.Files)
+	}
+
+	names := []string{}
+	for _, p := range got.Placeholders {
+		names = append(names, p.Name+"="+strings.Join(p.Rules, "+"))
+	}
+
+	if want := "appName=,codeName=regExp+alphaNumeric,repoOrg=alphaNumeric"; strings.Join(names, ",") != want {
+		tester.Errorf("got %v, want %v", strings.Join(names, ","), want)
+	}
+
+	if len(got.Validation) != 2 || got.Validation[0].Expression != "^[a-z][a-z0-9-]*$" {
+		tester.Errorf("got %v, want the validation rules of the template", got.Validation)
+	}
+
+	if len(got.Excludes) != 1 || len(got.Skip) != 1 {
+		tester.Errorf("got %v and %v, want the excludes and skip of the template", got.Excludes, got.Skip)
+	}
+}
+
+func TestCountFiles(tester *testing.T) {
+	dir := TmpDir + PS + "count-files"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir+PS+".git", DirMode)
+	_ = os.MkdirAll(dir+PS+"sub", DirMode)
+	_ = os.WriteFile(dir+PS+".git"+PS+"HEAD", []byte(""), 0644)
+	_ = os.WriteFile(dir+PS+TmplManifest, []byte("{}"), 0644)
+	_ = os.WriteFile(dir+PS+"a.txt", []byte(""), 0644)
+	_ = os.WriteFile(dir+PS+"sub"+PS+"b.txt", []byte(""), 0644)
+
+	got, err := CountFiles(dir)
+
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if got != 2 {
+		tester.Errorf("got %v, want 2", got)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// lfsPointerMaxSize Git LFS pointer files are always smaller than this.
+	lfsPointerMaxSize = 1024
+	// lfsPointerHeader First line of a Git LFS pointer file.
+	lfsPointerHeader = "version https://git-lfs.github.com/spec/v1\n"
+)
+
+// FindLfsPointers Return the files in a template, relative to dir, that are
+// Git LFS pointers instead of the content they point to. The .git directory
+// is skipped.
+func FindLfsPointers(dir string) ([]string, error) {
+	pointers := []string{}
+
+	e1 := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == gitDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		isPointer, e2 := IsLfsPointer(path)
+		if e2 != nil {
+			return e2
+		}
+
+		if isPointer {
+			rel, _ := filepath.Rel(dir, path)
+			pointers = append(pointers, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+
+	return pointers, e1
+}
+
+// IsLfsPointer Check if a file is a Git LFS pointer.
+func IsLfsPointer(file string) (bool, error) {
+	info, e1 := os.Stat(file)
+	if e1 != nil {
+		return false, e1
+	}
+
+	if info.Size() >= lfsPointerMaxSize {
+		return false, nil
+	}
+
+	f, e2 := os.Open(file)
+	if e2 != nil {
+		return false, e2
+	}
+	defer f.Close()
+
+	head := make([]byte, len(lfsPointerHeader))
+	if _, e := io.ReadFull(f, head); e != nil {
+		return false, nil
+	}
+
+	return bytes.Equal(head, []byte(lfsPointerHeader)), nil
+}
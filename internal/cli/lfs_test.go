@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindLfsPointers(tester *testing.T) {
+	got, err := FindLfsPointers(FixtureDir + PS + "lfs-01")
+
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	want := "assets/font.woff,logo.png"
+	if strings.Join(got, ",") != want {
+		tester.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestIsLfsPointer(tester *testing.T) {
+	var testCases = []struct {
+		name string
+		file string
+		want bool
+	}{
+		{"pointer", "logo.png", true},
+		{"template", "README.md", false},
+		{"otherVersion", "notes.txt", false},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := IsLfsPointer(FixtureDir + PS + "lfs-01" + PS + tc.file)
+
+			if err != nil {
+				t.Fatalf("got an unexpected err: %s", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// reRemoteHelper A git remote helper address, such as "ext::<command>".
+	reRemoteHelper = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.\-]*::`)
+	// reScpLike An scp-like Git location, "[user@]host:path". The host must be
+	// longer than one letter so a Windows drive is not mistaken for a host.
+	reScpLike = regexp.MustCompile(`^(?:([^@/:]+)@)?([^@/:]{2,}):(.*)$`)
+	// reUrlScheme The scheme of a URL, such as "https://" or "ssh://".
+	reUrlScheme = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.\-]*://`)
+)
+
+// IsRemoteLocation Check if a template location is a URL with a scheme (such
+// as https://, ssh:// or file://), an scp-like Git location
+// (git@github.com:kohirens/tmpltoapp.git), or a Git remote helper address
+// (ext::<command>). Anything else is a path on the local file system.
+func IsRemoteLocation(location string) bool {
+	location = strings.TrimSpace(location)
+
+	return reUrlScheme.MatchString(location) ||
+		reRemoteHelper.MatchString(location) ||
+		reScpLike.MatchString(location)
+}
+
+// IsScpLike Check if a location is an scp-like Git location, and not a URL.
+func IsScpLike(location string) bool {
+	return !reUrlScheme.MatchString(location) &&
+		!reRemoteHelper.MatchString(location) &&
+		reScpLike.MatchString(location)
+}
+
+// IsSshLocation Check if a location is reached over SSH, either an ssh://
+// URL or an scp-like location.
+func IsSshLocation(location string) bool {
+	lower := strings.ToLower(location)
+
+	return strings.HasPrefix(lower, "ssh://") ||
+		strings.HasPrefix(lower, "git+ssh://") ||
+		strings.HasPrefix(lower, "ssh+git://") ||
+		IsScpLike(location)
+}
+
+// ScpPath Return the path of an scp-like location, "org/repo.git" for
+// "git@github.com:org/repo.git".
+func ScpPath(location string) string {
+	return reScpLike.ReplaceAllString(location, "${3}")
+}
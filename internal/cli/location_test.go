@@ -0,0 +1,47 @@
+package cli
+
+import "testing"
+
+func TestIsRemoteLocation(tester *testing.T) {
+	var testCases = []struct {
+		location   string
+		wantRemote bool
+		wantScp    bool
+		wantSsh    bool
+	}{
+		{"https://github.com/kohirens/tmpltoapp.git", true, false, false},
+		{"ssh://git@github.com/kohirens/tmpltoapp.git", true, false, true},
+		{"git+ssh://github.com/kohirens/tmpltoapp.git", true, false, true},
+		{"git@github.com:kohirens/tmpltoapp.git", true, true, true},
+		{"github.com:kohirens/tmpltoapp.git", true, true, true},
+		{"file:///opt/templates/tmpl.git", true, false, false},
+		{"ext::ssh -i key example.com %S 'repo.git'", true, false, false},
+		{"/opt/templates/tmpl", false, false, false},
+		{"./tmpl", false, false, false},
+		{"C:\\Temp\\tmpl", false, false, false},
+		{"C:/Temp/tmpl", false, false, false},
+		{"~/templates/tmpl.git", false, false, false},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.location, func(t *testing.T) {
+			if got := IsRemoteLocation(tc.location); got != tc.wantRemote {
+				t.Errorf("remote: got %v, want %v", got, tc.wantRemote)
+			}
+
+			if got := IsScpLike(tc.location); got != tc.wantScp {
+				t.Errorf("scp-like: got %v, want %v", got, tc.wantScp)
+			}
+
+			if got := IsSshLocation(tc.location); got != tc.wantSsh {
+				t.Errorf("ssh: got %v, want %v", got, tc.wantSsh)
+			}
+		})
+	}
+}
+
+func TestScpPath(tester *testing.T) {
+	if got := ScpPath("git@github.com:kohirens/tmpltoapp.git"); got != "kohirens/tmpltoapp.git" {
+		tester.Errorf("got %v, want kohirens/tmpltoapp.git", got)
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib/log"
+	"os"
+	"time"
+)
+
+// lockExt Extension of the lock file of a cache entry.
+const lockExt = ".lock"
+
+// LockSettings Control how cache entries are locked, change them before
+// calling LockCacheEntry.
+var LockSettings = struct {
+	Poll    time.Duration // Wait between attempts to get a lock.
+	Stale   time.Duration // A lock not refreshed for this long was left by a process that died.
+	Timeout time.Duration // Give up waiting for a lock after this long.
+}{
+	Poll:    200 * time.Millisecond,
+	Stale:   2 * time.Minute,
+	Timeout: 10 * time.Minute,
+}
+
+// LockCacheEntry Lock an entry of the cache so that only this process
+// changes it, waiting for any other process to be done with it first. The
+// lock is kept fresh until the returned function releases it.
+func LockCacheEntry(cacheDir, name string) (func(), error) {
+	file := cacheDir + PS + name + lockExt
+	deadline := time.Now().Add(LockSettings.Timeout)
+	waiting := false
+
+	for {
+		unlock, e1 := lockFile(file)
+		if e1 != nil || unlock != nil {
+			return unlock, e1
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf(Errors.CacheLockTimeout, file, LockSettings.Timeout)
+		}
+
+		if !waiting {
+			log.Logf(Messages.CacheWaitLock, file)
+			waiting = true
+		}
+
+		time.Sleep(LockSettings.Poll)
+	}
+}
+
+// TryLockCacheEntry Lock an entry of the cache like LockCacheEntry, without
+// waiting. The release function is nil when another process has the lock.
+func TryLockCacheEntry(cacheDir, name string) (func(), error) {
+	return lockFile(cacheDir + PS + name + lockExt)
+}
+
+// lockFile Take a lock, breaking it when it is stale. The release function
+// is nil when another process has the lock.
+func lockFile(file string) (func(), error) {
+	for {
+		f, e1 := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if e1 == nil {
+			host, _ := os.Hostname()
+			_, _ = fmt.Fprintf(f, "%d@%v\n", os.Getpid(), host)
+			_ = f.Close()
+
+			return keepLock(file), nil
+		}
+
+		if !os.IsExist(e1) {
+			return nil, fmt.Errorf(Errors.CacheLock, file, e1.Error())
+		}
+
+		fi, e2 := os.Stat(file)
+		switch {
+		case os.IsNotExist(e2):
+			continue // Released in the meantime.
+		case e2 == nil && time.Since(fi.ModTime()) > LockSettings.Stale:
+			// Break a lock that is no longer refreshed.
+			breakStaleLock(file)
+			continue
+		}
+
+		return nil, nil
+	}
+}
+
+// breakStaleLock Remove a stale lock. It is moved aside first, so when two
+// processes break it at once only one does, and a lock taken in between the
+// check and the move is put back instead of removed.
+func breakStaleLock(file string) {
+	aside := fmt.Sprintf("%v.%d-%d.stale", file, os.Getpid(), time.Now().UnixNano())
+	if e := os.Rename(file, aside); e != nil {
+		return // Another process broke it.
+	}
+	defer func() { _ = os.Remove(aside) }()
+
+	if fi, e := os.Stat(aside); e == nil && time.Since(fi.ModTime()) <= LockSettings.Stale {
+		// Fails when yet another process has taken the lock since.
+		_ = os.Link(aside, file)
+		return
+	}
+
+	log.Logf(Messages.CacheStaleLock, file)
+}
+
+// keepLock Refresh a lock, so it is not taken as stale, until it is released.
+func keepLock(file string) func() {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(LockSettings.Stale / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case t := <-ticker.C:
+				_ = os.Chtimes(file, t, t)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		_ = os.Remove(file)
+	}
+}
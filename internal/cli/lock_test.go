@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fastLocks Make locks poll, go stale and time out quickly during a test.
+func fastLocks(t *testing.T, stale, timeout time.Duration) {
+	prev := LockSettings
+	LockSettings.Poll = 5 * time.Millisecond
+	LockSettings.Stale = stale
+	LockSettings.Timeout = timeout
+	t.Cleanup(func() { LockSettings = prev })
+}
+
+func TestLockCacheEntry(tester *testing.T) {
+	fastLocks(tester, time.Minute, 5*time.Second)
+	dir := TmpDir + PS + "cache-lock"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	// Each holder of the lock must have it alone.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	holders, most := 0, 0
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			unlock, err := LockCacheEntry(dir, "web-main")
+			if err != nil {
+				tester.Errorf("got an unexpected err: %s", err)
+				return
+			}
+
+			mu.Lock()
+			holders++
+			if holders > most {
+				most = holders
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+
+			unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if most != 1 {
+		tester.Errorf("got %v holders of the lock at once, want 1", most)
+	}
+
+	if _, e := os.Stat(dir + PS + "web-main" + lockExt); !os.IsNotExist(e) {
+		tester.Errorf("got a lock file left behind")
+	}
+}
+
+func TestLockCacheEntryTimeout(tester *testing.T) {
+	fastLocks(tester, time.Minute, 50*time.Millisecond)
+	dir := TmpDir + PS + "cache-lock-timeout"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	unlock, err := LockCacheEntry(dir, "web-main")
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+	defer unlock()
+
+	if _, e := LockCacheEntry(dir, "web-main"); e == nil {
+		tester.Errorf("got no error, want a timeout")
+	}
+
+	// Other entries are not locked.
+	unlock2, e2 := LockCacheEntry(dir, "web-dev")
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+	unlock2()
+}
+
+func TestLockCacheEntryStale(tester *testing.T) {
+	fastLocks(tester, time.Minute, time.Second)
+	dir := TmpDir + PS + "cache-lock-stale"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	// Left by a process that died an hour ago.
+	file := dir + PS + "web-main" + lockExt
+	_ = os.WriteFile(file, []byte("1@host\n"), 0644)
+	old := time.Now().Add(-time.Hour)
+	_ = os.Chtimes(file, old, old)
+
+	unlock, err := LockCacheEntry(dir, "web-main")
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+	unlock()
+}
+
+func TestBreakStaleLock(runner *testing.T) {
+	fastLocks(runner, time.Minute, time.Second)
+	dir := TmpDir + PS + "cache-lock-break"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	testCases := []struct {
+		name     string
+		age      time.Duration
+		wantKept bool
+	}{
+		{"stale", time.Hour, false},
+		// Taken by another process after this one found the old lock stale.
+		{"takenAgain", 0, true},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			file := dir + PS + tc.name + lockExt
+			_ = os.WriteFile(file, []byte("1@host\n"), 0644)
+			mTime := time.Now().Add(-tc.age)
+			_ = os.Chtimes(file, mTime, mTime)
+
+			breakStaleLock(file)
+
+			if _, e := os.Stat(file); os.IsNotExist(e) == tc.wantKept {
+				t.Errorf("got lock kept %v, want %v", !tc.wantKept, tc.wantKept)
+			}
+
+			if left, _ := filepath.Glob(file + ".*"); len(left) > 0 {
+				t.Errorf("the lock was left moved aside as %v", left)
+			}
+		})
+	}
+}
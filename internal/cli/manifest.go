@@ -34,7 +34,7 @@ func GenerateATemplateManifest(tmplPath string, fec *stdlib.FileExtChecker, excl
 	for _, tmpl := range templates {
 		fmt.Printf("checking %v\n", tmpl)
 
-		t, e := template.ParseFiles(tmpl)
+		t, e := template.New(filepath.Base(tmpl)).Funcs(funcMap).ParseFiles(tmpl)
 		if e != nil {
 			return nil, fmt.Errorf(Errors.parsingFile, tmpl, e.Error())
 		}
@@ -61,6 +61,11 @@ func ManifestParseDir(path string, fec *stdlib.FileExtChecker, excludes []string
 	nPath := strings.ReplaceAll(path, "/", PS)
 	nPath = strings.ReplaceAll(nPath, "\\", PS)
 
+	ignore, e0 := ReadIgnoreFile(nPath)
+	if e0 != nil {
+		return nil, e0
+	}
+
 	var files []string
 	i := 0
 	// Recursively walk the template directory.
@@ -68,6 +73,17 @@ func ManifestParseDir(path string, fec *stdlib.FileExtChecker, excludes []string
 		i++
 		//fmt.Printf("%-2d %v\n", i, fPath)
 
+		// Leave out what is not part of the template output.
+		if rel, e := filepath.Rel(nPath, fPath); e == nil && rel != "." && info != nil {
+			rel = filepath.ToSlash(rel)
+			if rel == IgnoreFile || Ignored(ignore, rel, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
 		file, e1 := filterFile(fPath, nPath, info, err, fec, excludes)
 		if err != nil {
 			return e1
@@ -134,8 +150,8 @@ func filterFile(sourcePath, nPath string, info os.FileInfo, wErr error, fec *std
 
 // ListTemplateFields list actions in Go templates. See SO answer: https://stackoverflow.com/a/40584967/419097
 func listNodeFields(node parse.Node, res map[string]string) {
-	if node.Type() == parse.NodeAction {
-		res[strings.Trim(node.String(), "{}.")] = ""
+	if an, ok := node.(*parse.ActionNode); ok {
+		listPipeFields(an.Pipe, res)
 	}
 
 	if ln, ok := node.(*parse.ListNode); ok {
@@ -145,6 +161,28 @@ func listNodeFields(node parse.Node, res map[string]string) {
 	}
 }
 
+// listPipeFields List the fields used in a pipeline, such as appName in
+// {{ .appName | kebabCase }}.
+func listPipeFields(pipe *parse.PipeNode, res map[string]string) {
+	if pipe == nil {
+		return
+	}
+
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.FieldNode:
+				// Skip what tmpltoapp sets, such as .Data.
+				if !isReserved(a.Ident[0]) {
+					res[strings.Join(a.Ident, ".")] = ""
+				}
+			case *parse.PipeNode:
+				listPipeFields(a, res)
+			}
+		}
+	}
+}
+
 type templateSchema struct {
 	Placeholders []byte
 }
@@ -3,6 +3,7 @@ package cli
 import (
 	"github.com/kohirens/stdlib"
 	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
 	"reflect"
 	"testing"
 )
@@ -42,3 +43,40 @@ func TestGenerateATemplateJson(runner *testing.T) {
 		})
 	}
 }
+
+func TestGenerateATemplateJsonWithFuncs(runner *testing.T) {
+	fec, _ := stdlib.NewFileExtChecker(&[]string{".empty", "exe", "gif", "jpg", "mp3", "pdf", "png", "tiff", "wmv"}, &[]string{})
+
+	testCases := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{"plainField", "{{ .appName }}", map[string]string{"appName": ""}},
+		{"pipeline", "{{ .appName | kebabCase }}", map[string]string{"appName": ""}},
+		{"funcArgs", `{{ default "8080" .port }} {{ ternary "a" "b" .on }}`, map[string]string{"on": "", "port": ""}},
+		{"nestedPipe", "{{ quote (.org | snakeCase) }}", map[string]string{"org": ""}},
+		{"skipReserved", "{{ .Meta.Year }} {{ .Data.regions | toJson }}", map[string]string{}},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			dir := TmpDir + PS + "manifest-funcs-" + tc.name
+			_ = os.RemoveAll(dir)
+			_ = os.MkdirAll(dir, DirMode)
+			if e := os.WriteFile(dir+PS+"README.md", []byte(tc.content), 0744); e != nil {
+				t.Fatal(e)
+			}
+
+			got, err := GenerateATemplateManifest(dir, fec, []string{})
+
+			if err != nil {
+				t.Fatalf("want nil, got: %q", err.Error())
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -2,65 +2,149 @@ package cli
 
 // Messages helpful info to std out
 var Messages = struct {
-	ActualArgs            string
-	CloningToCache        string
-	ConfigFileExist       string
-	CurrentVersion        string
-	CurrentVersionInfo    string
-	GitCheckout           string
-	MadeNewConfig         string
-	NumNonFlagArgs        string
-	NumParsedFlags        string
-	OutPathExist          string
-	OutRepoDir            string
-	ProvideValues         string
-	PrintAllFlags         string
-	PrintFlag             string
-	PlaceholderAnswer     string
-	PlaceholderAnswerStat string
-	PlaceholderHasAnswer  string
-	RefInfo               string
-	RemoteTagDbug1        string
-	ReadConfig            string
-	RepoDir               string
-	RepoInfo              string
-	RunningCommand        string
-	SaveData              string
-	SkipFile              string
-	SubCommands           string
-	UnknownFileType       string
-	UsageHeader           string
-	UsingCache            string
-	VerboseLevelInfo      string
+	ActualArgs              string
+	AliasShadowed           string
+	CacheInUse              string
+	CacheRemoved            string
+	CacheStaleLock          string
+	CacheWaitLock           string
+	CacheMigrated           string
+	CacheNotRecorded        string
+	ChecksumFileStatus      string
+	ChecksumFileUnusable    string
+	ChecksumVerified        string
+	CloningToCache          string
+	ConfigFileExist         string
+	ConfigNotPrivate        string
+	CredentialsNotHttps     string
+	CurrentVersion          string
+	CurrentVersionInfo      string
+	DownloadNotModified     string
+	DownloadProgress        string
+	DownloadProgressUnknown string
+	DownloadResume          string
+	DownloadRetry           string
+	GitCheckout             string
+	GitCredentials          string
+	GitLfsPull              string
+	GitLfsSkipped           string
+	GitNotInstalled         string
+	GitResolvedRef          string
+	GitSsh                  string
+	GitSubmodules           string
+	GitUnsigned             string
+	GitVerify               string
+	GitVerifySkipped        string
+	MadeSnapshot            string
+	MadeNewConfig           string
+	NetrcUnreadable         string
+	NoChecksum              string
+	NoSignature             string
+	NoTrustedKeys           string
+	NumNonFlagArgs          string
+	NumParsedFlags          string
+	OutPathExist            string
+	OutRepoDir              string
+	OfflineMode             string
+	ProvideValues           string
+	PrintAllFlags           string
+	PrintFlag               string
+	PlaceholderAnswer       string
+	PlaceholderAnswerStat   string
+	PlaceholderHasAnswer    string
+	PickedVersion           string
+	PolicyCheck             string
+	RefInfo                 string
+	RemoteTagDbug1          string
+	ReadConfig              string
+	ReadPolicy              string
+	RepoDir                 string
+	RepoInfo                string
+	RunningCommand          string
+	SaveData                string
+	SavedRecord             string
+	SignatureVerified       string
+	SkipFile                string
+	SubCommands             string
+	UnknownFileType         string
+	UsageHeader             string
+	UsingCache              string
+	UsingAlias              string
+	UsingCachedDownload     string
+	UsingSnapshot           string
+	UsingMirror             string
+	VerboseLevelInfo        string
 }{
-	ActualArgs:            "actual arguments passed in: %v",
-	CloningToCache:        "no cache; cloning %v to %v",
-	ConfigFileExist:       "config file %q exist",
-	CurrentVersion:        "%v, %v",
-	CurrentVersionInfo:    "version: %v, %v",
-	GitCheckout:           "git checkout %s",
-	MadeNewConfig:         "saved %d bytes to a new config %q",
-	NumNonFlagArgs:        "number of non-flag arguments passed in: %d",
-	NumParsedFlags:        "number of parsed flags = %v",
-	OutRepoDir:            "repoDir = %v",
-	OutPathExist:          "out-path already exits %q",
-	ProvideValues:         "note: entering no value will render the placeholder with an empty string",
-	PrintAllFlags:         "printing all flags set:",
-	PrintFlag:             "\t%s = %v (default= %v)",
-	PlaceholderAnswer:     "%v = %q",
-	PlaceholderAnswerStat: "please provide values for %v placeholders",
-	PlaceholderHasAnswer:  "placeholder %v has a value of %q, so skipping",
-	RefInfo:               "ref = %v ",
-	RemoteTagDbug1:        "remote tag: %v",
-	ReadConfig:            "reading config file %v",
-	RepoDir:               "repoDir = %q",
-	RepoInfo:              "repo = %q; %q",
-	RunningCommand:        "running command %s",
-	SaveData:              "%save data: s",
-	SkipFile:              "skipping: %v",
-	SubCommands:           "sub-commands:\n",
-	UsageHeader:           "Usage: %v -[options] [args]\n",
-	UsingCache:            "using cache %v",
-	UnknownFileType:       "will skip and not process through template engine; could not detect file type for %v",
-	VerboseLevelInfo:      "verbose level: %v",
+	ActualArgs:              "actual arguments passed in: %v",
+	AliasShadowed:           "%v is a path, so it is used instead of the alias of the same name",
+	CacheInUse:              "%v is in use by another process, it was left in the cache",
+	CacheRemoved:            "removed %v from the cache",
+	CacheStaleLock:          "removing the stale lock %v",
+	CacheWaitLock:           "waiting for another process to release the lock %v",
+	CacheMigrated:           "moved %v to %v in the cache",
+	CacheNotRecorded:        "could not record the use of the cache: %v",
+	ChecksumFileStatus:      "no checksum file at %v, HTTP status code %d",
+	ChecksumFileUnusable:    "could not use the checksum file %v: %v",
+	ChecksumVerified:        "sha256 checksum verified for %v",
+	CloningToCache:          "no cache; cloning %v to %v",
+	ConfigFileExist:         "config file %q exist",
+	ConfigNotPrivate:        "could not make the config %v, which has credentials, readable only by you: %v",
+	CredentialsNotHttps:     "not sending credentials to %v, it is not HTTPS",
+	CurrentVersion:          "%v, %v",
+	CurrentVersionInfo:      "version: %v, %v",
+	DownloadNotModified:     "%v has not changed, using cache %v",
+	DownloadProgress:        "downloading %v: %3d%% (%d of %d bytes)",
+	DownloadProgressUnknown: "downloading %v: %d bytes",
+	DownloadResume:          "resuming download of %v at byte %d",
+	DownloadRetry:           "retrying download of %v in %v, because: %v",
+	GitCheckout:             "git checkout %s",
+	GitCredentials:          "using credentials for %v",
+	GitLfsPull:              "getting Git LFS files of %v",
+	GitLfsSkipped:           "could not get Git LFS files: %v",
+	GitNotInstalled:         "git is not installed, using the built-in git backend",
+	GitResolvedRef:          "ref %q resolved to commit %v",
+	GitSsh:                  "git will use ssh command %q",
+	GitSubmodules:           "updating the submodules of %v",
+	GitUnsigned:             "%v is not signed, there is no signature to verify",
+	GitVerify:               "verifying git signature of %v",
+	GitVerifySkipped:        "could not verify the signature of %v: %v",
+	MadeSnapshot:            "made the snapshot %v",
+	MadeNewConfig:           "saved %d bytes to a new config %q",
+	NetrcUnreadable:         "could not read netrc file %v: %v",
+	NoChecksum:              "no checksum found for %v, the download will not be verified",
+	NoSignature:             "no signature found for %v, skipping signature verification",
+	NoTrustedKeys:           "no trusted keys configured, skipping signature verification of %v",
+	NumNonFlagArgs:          "number of non-flag arguments passed in: %d",
+	NumParsedFlags:          "number of parsed flags = %v",
+	OutRepoDir:              "repoDir = %v",
+	OfflineMode:             "offline mode is on, only the cache is used",
+	OutPathExist:            "out-path already exits %q",
+	ProvideValues:           "note: entering no value will render the placeholder with an empty string",
+	PrintAllFlags:           "printing all flags set:",
+	PrintFlag:               "\t%s = %v (default= %v)",
+	PlaceholderAnswer:       "%v = %q",
+	PlaceholderAnswerStat:   "please provide values for %v placeholders",
+	PlaceholderHasAnswer:    "placeholder %v has a value of %q, so skipping",
+	PickedVersion:           "using version %v of the template",
+	PolicyCheck:             "checking %v against the template policy",
+	RefInfo:                 "ref = %v ",
+	RemoteTagDbug1:          "remote tag: %v",
+	ReadConfig:              "reading config file %v",
+	ReadPolicy:              "reading system policy file %v",
+	RepoDir:                 "repoDir = %q",
+	RepoInfo:                "repo = %q; %q",
+	RunningCommand:          "running command %s",
+	SaveData:                "%save data: s",
+	SavedRecord:             "saved a record of the template used to %v",
+	SignatureVerified:       "signature verified for %v",
+	SkipFile:                "skipping: %v",
+	SubCommands:             "sub-commands:\n",
+	UsageHeader:             "Usage: %v -[options] [args]\n",
+	UsingCache:              "using cache %v",
+	UsingAlias:              "using the template %v of the alias %v",
+	UsingCachedDownload:     "using verified download from cache %v",
+	UsingSnapshot:           "using the snapshot %v",
+	UsingMirror:             "%v was imported from a mirror, using it as in offline mode",
+	UnknownFileType:         "will skip and not process through template engine; could not detect file type for %v",
+	VerboseLevelInfo:        "verbose level: %v",
 }
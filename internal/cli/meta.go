@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// MetaKey Name of what tmpltoapp knows about a run in a template, such as
+// {{ .Meta.Template.Version }}.
+const MetaKey = "Meta"
+
+// Clock Returns the time of a run. It is SOURCE_DATE_EPOCH, in seconds since
+// the Unix epoch, when set so output can be made again the same, otherwise the
+// current time. Tests replace it.
+var Clock = func() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, e := strconv.ParseInt(epoch, 10, 64); e == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+	}
+
+	return time.Now().UTC()
+}
+
+// Meta What tmpltoapp knows about a run, available to templates as .Meta.
+type Meta struct {
+	AppCommit  string       // Commit of tmpltoapp.
+	AppVersion string       // Version of tmpltoapp.
+	Arch       string       // Architecture tmpltoapp runs on, such as amd64.
+	Date       string       // Date of the run, as YYYY-MM-DD.
+	OS         string       // Operating system tmpltoapp runs on, such as linux.
+	OutDir     string       // Base name of the output directory.
+	OutPath    string       // Output directory, as given.
+	Template   MetaTemplate // The template being run.
+	Time       time.Time    // Time of the run, in UTC.
+	Year       int          // Year of the run.
+}
+
+// MetaTemplate What tmpltoapp knows about the template of a run.
+type MetaTemplate struct {
+	Commit  string // Commit of a git template that was checked out.
+	Path    string // Location of the template, redacted.
+	Ref     string // Ref of a git template, as given.
+	Type    string // Type of template; zip, git or dir.
+	Version string // Version from the template manifest.
+}
+
+// NewMeta Collect what is known about a run that makes outPath at now.
+func NewMeta(cfg *Config, outPath string, now time.Time) *Meta {
+	now = now.UTC()
+
+	m := &Meta{
+		AppCommit:  cfg.CommitHash,
+		AppVersion: cfg.CurrentVersion,
+		Arch:       runtime.GOARCH,
+		Date:       now.Format(time.DateOnly),
+		OS:         runtime.GOOS,
+		OutDir:     filepath.Base(outPath),
+		OutPath:    outPath,
+		Template: MetaTemplate{
+			Path: RedactUrl(cfg.TmplPath),
+			Type: cfg.TmplType,
+		},
+		Time: now,
+		Year: now.Year(),
+	}
+
+	if cfg.TmplJson != nil {
+		m.Template.Version = cfg.TmplJson.Version
+	}
+
+	if cfg.TmplType == "git" {
+		m.Template.Commit = cfg.TmplCommit
+		m.Template.Ref = cfg.Branch
+	}
+
+	return m
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// MirrorManifest Name of the file, in a mirror archive, that lists the
+	// cache entries in it.
+	MirrorManifest = "mirror.json"
+	// BundleExt Extension of a git bundle in a mirror archive.
+	BundleExt = ".bundle"
+)
+
+// Mirror The cache entries packed in a mirror archive, to move templates to a
+// machine with no access to where they came from.
+type Mirror struct {
+	Created time.Time     `json:"created"`
+	Entries []*CacheEntry `json:"entries"`
+}
+
+// ExportMirror Pack cache entries into a mirror archive. A zip template goes
+// in as it is, with its download metadata, a git template as the bundle made
+// for it, mapped by the entry name in bundles.
+func ExportMirror(file, cacheDir string, entries []*CacheEntry, bundles map[string]string) error {
+	m := &Mirror{Created: time.Now().UTC(), Entries: entries}
+	files := map[string]string{}
+
+	for _, ce := range entries {
+		switch ce.Type {
+		case "git":
+			bundle, ok := bundles[ce.Name]
+			if !ok {
+				return fmt.Errorf(Errors.MirrorNoBundle, ce.Name)
+			}
+			files[ce.Name+BundleExt] = bundle
+		case "zip":
+			p := cacheDir + PS + ce.Name
+			files[ce.Name] = p
+			if stdlib.PathExist(p + metaExt) {
+				files[ce.Name+metaExt] = p + metaExt
+			}
+		}
+	}
+
+	data, e1 := json.MarshalIndent(m, "", "    ")
+	if e1 != nil {
+		return fmt.Errorf(Errors.encodingJson, MirrorManifest, e1.Error())
+	}
+
+	// Written to a temporary name, so a failed export leaves no archive.
+	tmp := file + partExt
+	if e := writeMirror(tmp, data, files); e != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf(Errors.MirrorWrite, file, e.Error())
+	}
+
+	return os.Rename(tmp, file)
+}
+
+// ReadMirror Extract a mirror archive to a directory and return the cache
+// entries in it.
+func ReadMirror(file, dir string) (*Mirror, error) {
+	archive, e1 := zip.OpenReader(file)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.MirrorRead, file, e1.Error())
+	}
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		// Everything is at the root of the archive.
+		if f.Name != filepath.Base(f.Name) || strings.HasPrefix(f.Name, ".") {
+			return nil, fmt.Errorf(Errors.MirrorBadEntry, file, f.Name)
+		}
+
+		if e := extractFile(f, dir+PS+f.Name); e != nil {
+			return nil, fmt.Errorf(Errors.MirrorRead, file, e.Error())
+		}
+	}
+
+	content, e2 := os.ReadFile(dir + PS + MirrorManifest)
+	if e2 != nil {
+		return nil, fmt.Errorf(Errors.MirrorRead, file, e2.Error())
+	}
+
+	m := &Mirror{}
+	if e := json.Unmarshal(content, m); e != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, MirrorManifest, e.Error())
+	}
+
+	for _, ce := range m.Entries {
+		if (ce.Type != "git" && ce.Type != "zip") || !isCacheName(ce.Name, ce.Type) {
+			return nil, fmt.Errorf(Errors.MirrorBadEntry, file, ce.Name)
+		}
+	}
+
+	return m, nil
+}
+
+// ImportZip Move a zip template, and its download metadata, from a
+// directory a mirror was extracted to into the cache.
+func ImportZip(dir, cacheDir string, ce *CacheEntry) error {
+	dst := cacheDir + PS + ce.Name
+
+	if stdlib.PathExist(dir + PS + ce.Name + metaExt) {
+		if e := os.Rename(dir+PS+ce.Name+metaExt, dst+metaExt); e != nil {
+			return e
+		}
+	}
+
+	return os.Rename(dir+PS+ce.Name, dst)
+}
+
+// extractFile Write a file in a zip to dst.
+func extractFile(f *zip.File, dst string) error {
+	src, e1 := f.Open()
+	if e1 != nil {
+		return e1
+	}
+	defer src.Close()
+
+	out, e2 := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if e2 != nil {
+		return e2
+	}
+
+	if _, e := io.Copy(out, src); e != nil {
+		_ = out.Close()
+		return e
+	}
+
+	return out.Close()
+}
+
+// writeMirror Write the manifest and files of a mirror to a zip.
+func writeMirror(file string, manifest []byte, files map[string]string) error {
+	f, e1 := os.Create(file)
+	if e1 != nil {
+		return e1
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+
+	w, e2 := zw.Create(MirrorManifest)
+	if e2 != nil {
+		return e2
+	}
+
+	if _, e := w.Write(manifest); e != nil {
+		return e
+	}
+
+	for name, p := range files {
+		if e := addToZip(zw, name, p); e != nil {
+			return e
+		}
+	}
+
+	if e := zw.Close(); e != nil {
+		return e
+	}
+
+	return f.Close()
+}
+
+// addToZip Add a file to a zip. Bundles and zips are already compressed, so
+// they are stored.
+func addToZip(zw *zip.Writer, name, file string) error {
+	src, e1 := os.Open(file)
+	if e1 != nil {
+		return e1
+	}
+	defer src.Close()
+
+	w, e2 := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+	if e2 != nil {
+		return e2
+	}
+
+	_, e3 := io.Copy(w, src)
+
+	return e3
+}
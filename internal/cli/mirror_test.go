@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"archive/zip"
+	"github.com/kohirens/stdlib"
+	"os"
+	"testing"
+)
+
+func TestExportMirror(tester *testing.T) {
+	cacheDir := TmpDir + PS + "cache-mirror-export"
+	_ = os.RemoveAll(cacheDir)
+	_ = os.MkdirAll(cacheDir, DirMode)
+	_ = os.WriteFile(cacheDir+PS+"web-0123456789abcdef.zip", []byte("zip"), 0644)
+	_ = os.WriteFile(cacheDir+PS+"web-0123456789abcdef.zip"+metaExt, []byte("{}"), 0644)
+	_ = os.WriteFile(cacheDir+PS+"app.bundle", []byte("bundle"), 0644)
+
+	entries := []*CacheEntry{
+		{Name: "web-0123456789abcdef.zip", Source: "https://example.com/web.zip", Type: "zip"},
+		{Commit: "b437757d8da54ada2e622996af0911ac5697242b", Name: "app-main-0123456789abcdef", Ref: "main", Source: "https://example.com/app.git", Type: "git"},
+	}
+	file := TmpDir + PS + "export.mirror.zip"
+
+	if e := ExportMirror(file, cacheDir, entries, map[string]string{}); e == nil {
+		tester.Errorf("got no error, want one for the missing bundle")
+	}
+
+	err := ExportMirror(file, cacheDir, entries, map[string]string{"app-main-0123456789abcdef": cacheDir + PS + "app.bundle"})
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	dir := TmpDir + PS + "mirror-read"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+
+	m, e2 := ReadMirror(file, dir)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if len(m.Entries) != 2 || m.Entries[1].Commit != entries[1].Commit {
+		tester.Errorf("got %v, want the entries exported", m.Entries)
+	}
+
+	for _, f := range []string{"web-0123456789abcdef.zip", "web-0123456789abcdef.zip" + metaExt, "app-main-0123456789abcdef" + BundleExt} {
+		if !stdlib.PathExist(dir + PS + f) {
+			tester.Errorf("got no %v in the mirror", f)
+		}
+	}
+
+	importDir := TmpDir + PS + "cache-mirror-import"
+	_ = os.RemoveAll(importDir)
+	_ = os.MkdirAll(importDir, DirMode)
+
+	if e := ImportZip(dir, importDir, m.Entries[0]); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !stdlib.PathExist(importDir+PS+"web-0123456789abcdef.zip") || !stdlib.PathExist(importDir+PS+"web-0123456789abcdef.zip"+metaExt) {
+		tester.Errorf("got the zip, or its metadata, missing from the cache")
+	}
+}
+
+func TestReadMirrorBadEntry(tester *testing.T) {
+	var testCases = []struct {
+		name  string
+		entry string
+		data  string
+	}{
+		{"pathInArchive", "../evil", "x"},
+		{"pathInManifest", MirrorManifest, `{"entries":[{"name":"../evil","type":"zip"}]}`},
+		{"badType", MirrorManifest, `{"entries":[{"name":"web-0123456789abcdef","type":"dir"}]}`},
+		{"dotDot", MirrorManifest, `{"entries":[{"name":"..","type":"git"}]}`},
+		{"dot", MirrorManifest, `{"entries":[{"name":".","type":"git"}]}`},
+		{"snapshots", MirrorManifest, `{"entries":[{"name":"snapshots","type":"git"}]}`},
+		{"index", MirrorManifest, `{"entries":[{"name":"` + CacheIndex + `","type":"zip"}]}`},
+		{"notCacheKey", MirrorManifest, `{"entries":[{"name":"web","type":"git"}]}`},
+		{"zipNameForGit", MirrorManifest, `{"entries":[{"name":"web-0123456789abcdef.zip","type":"git"}]}`},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			file := TmpDir + PS + tc.name + ".mirror.zip"
+			f, _ := os.Create(file)
+			zw := zip.NewWriter(f)
+			w, _ := zw.Create(tc.entry)
+			_, _ = w.Write([]byte(tc.data))
+			_ = zw.Close()
+			_ = f.Close()
+
+			dir := TmpDir + PS + "mirror-" + tc.name
+			_ = os.RemoveAll(dir)
+			_ = os.MkdirAll(dir, DirMode)
+
+			if _, e := ReadMirror(file, dir); e == nil {
+				t.Errorf("got no error, want one")
+			}
+		})
+	}
+}
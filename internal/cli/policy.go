@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// PolicyFileEnv Environment variable to change the location of the
+// system-wide policy file.
+const PolicyFileEnv = "TMPLTOAPP_POLICY_FILE"
+
+// Policy Patterns of hosts and paths templates are allowed, or denied, to come
+// from. For example "github.com/my-org/*" or "/opt/templates/**".
+type Policy struct {
+	AllowList []string // When not empty, only templates matching a pattern are allowed.
+	DenyList  []string // Templates matching a pattern are never allowed.
+}
+
+// Check Return an error when a template source is not allowed by the policy.
+// The deny-list always wins over the allow-list.
+func (p *Policy) Check(source string) error {
+	if p == nil {
+		return nil
+	}
+
+	normSrc := normalizeSource(source)
+	log.Dbugf(Messages.PolicyCheck, normSrc)
+
+	for _, pattern := range p.DenyList {
+		if matchSource(pattern, normSrc) {
+			return fmt.Errorf(Errors.PathDenied, Redact(source), pattern)
+		}
+	}
+
+	if len(p.AllowList) < 1 {
+		return nil
+	}
+
+	for _, pattern := range p.AllowList {
+		if matchSource(pattern, normSrc) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(Errors.PathNotAllowed, Redact(source))
+}
+
+// LoadPolicy Combine the user settings with the system-wide policy file. The
+// system allow-list replaces the one from the user, and the deny-lists are
+// combined, so a user cannot loosen the system policy.
+func LoadPolicy(usrOpts *UserOptions, systemFile string) (*Policy, error) {
+	p := &Policy{}
+	if usrOpts != nil {
+		p.AllowList = usrOpts.AllowList
+		p.DenyList = usrOpts.DenyList
+	}
+
+	if systemFile == "" || !stdlib.PathExist(systemFile) {
+		return p, nil
+	}
+
+	log.Infof(Messages.ReadPolicy, systemFile)
+
+	content, e1 := ioutil.ReadFile(systemFile)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, systemFile, e1.Error())
+	}
+
+	sys := &Policy{}
+	if e := json.Unmarshal(content, sys); e != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, systemFile, e.Error())
+	}
+
+	if sys.AllowList != nil {
+		p.AllowList = sys.AllowList
+	}
+
+	p.DenyList = append(append([]string{}, sys.DenyList...), p.DenyList...)
+
+	return p, nil
+}
+
+// SystemPolicyFile Location of the system-wide policy file.
+func SystemPolicyFile(appName string) string {
+	if f, ok := os.LookupEnv(PolicyFileEnv); ok {
+		return f
+	}
+
+	if runtime.GOOS == "windows" {
+		return os.Getenv("ProgramData") + PS + appName + PS + "policy.json"
+	}
+
+	return "/etc/" + appName + "/policy.json"
+}
+
+// matchSource Check if a glob pattern matches a normalized source, or any
+// directory above it. A "*" matches within a path segment and "**" matches
+// across segments.
+func matchSource(pattern, normSrc string) bool {
+	normPattern, isUrl := normalizeUrl(pattern)
+	if !isUrl {
+		normPattern = filepath.ToSlash(strings.TrimSpace(pattern))
+		if strings.HasPrefix(normPattern, "/") || filepath.IsAbs(pattern) {
+			normPattern = path.Clean(normPattern)
+		} else {
+			// A pattern that is not a path starts with a host.
+			host, rest, _ := strings.Cut(normPattern, "/")
+			normPattern = hostPath(host, rest)
+		}
+	}
+
+	re := regexp.QuoteMeta(normPattern)
+	re = strings.ReplaceAll(re, `\*\*`, `.*`)
+	re = strings.ReplaceAll(re, `\*`, `[^/]*`)
+	re = strings.ReplaceAll(re, `\?`, `[^/]`)
+
+	ok, _ := regexp.MatchString("^"+re+"(/.*)?$", normSrc)
+
+	return ok
+}
+
+// normalizeSource Reduce a template URL to "host/path", or a local path to an
+// absolute path, so it can be matched against policy patterns.
+func normalizeSource(source string) string {
+	if src, isUrl := normalizeUrl(source); isUrl {
+		return src
+	}
+
+	src := strings.TrimSpace(source)
+	if abs, e := filepath.Abs(src); e == nil {
+		src = abs
+	}
+
+	return strings.TrimSuffix(filepath.ToSlash(src), "/")
+}
+
+// normalizeUrl Reduce a URL, or scp-like Git location, to "host/path" without
+// the scheme, user info, port or ".git" suffix. Returns false when it is not
+// a URL.
+func normalizeUrl(location string) (string, bool) {
+	src := strings.TrimSpace(location)
+
+	switch {
+	case reUrlScheme.MatchString(src):
+		src = reUrlScheme.ReplaceAllString(src, "")
+		hostEnd := strings.IndexAny(src+"/", "/")
+		host, rest := src[:hostEnd], src[hostEnd:]
+		// Drop the user info and port.
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+		if i := strings.Index(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		src = hostPath(host, rest)
+	case reScpLike.MatchString(src):
+		src = hostPath(reScpLike.ReplaceAllString(src, "${2}"), reScpLike.ReplaceAllString(src, "${3}"))
+	default:
+		return "", false
+	}
+
+	src = strings.TrimSuffix(src, ".git")
+
+	return src, true
+}
+
+// hostPath Join a host and path as "host/path", with the host in lower case
+// and the path cleaned, so "GitHub.com" or "/org/../other-org" cannot slip
+// past a pattern. A ".." never climbs above the host.
+func hostPath(host, p string) string {
+	host = strings.ToLower(host)
+
+	p = path.Clean("/" + p)
+	if p == "/" {
+		return host
+	}
+
+	return host + p
+}
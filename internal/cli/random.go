@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	mrand "math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	alphaNum = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	// pwSymbols Symbols safe to put in a .env file or shell without quotes.
+	pwSymbols = "!%*+-.=@^_~"
+	// pwMinLen Shortest password, one letter of each case, a digit and a symbol.
+	pwMinLen = 4
+)
+
+// Generator Makes the values of the uuid, randAlphaNum, randInt and password
+// template functions. The same seed makes the same values, and values it is
+// given are used instead of making them, so a run can be made again from its
+// record.
+//
+// A value given a name, such as {{ uuid "projectId" }}, is the same everywhere
+// the name is used. Values without a name are kept by the function and the
+// order they were made in, such as "uuid#2".
+type Generator struct {
+	counts map[string]int
+	mu     sync.Mutex
+	rnd    *mrand.Rand
+	secret map[string]bool // Keys of the passwords.
+	seed   int64
+	values map[string]string
+}
+
+// NewGenerator Make the values of a run from seed, values replace those made.
+func NewGenerator(seed int64, values map[string]string) *Generator {
+	// ChaCha8 so a password is as hard to guess as the seed.
+	key := sha256.Sum256(binary.BigEndian.AppendUint64(nil, uint64(seed)))
+
+	g := &Generator{
+		counts: map[string]int{},
+		rnd:    mrand.New(mrand.NewChaCha8(key)),
+		secret: map[string]bool{},
+		seed:   seed,
+		values: make(map[string]string, len(values)),
+	}
+
+	for k, v := range values {
+		g.values[k] = v
+	}
+
+	return g
+}
+
+// RandomSeed Make a seed for a run that was not given one.
+func RandomSeed() (int64, error) {
+	b := make([]byte, 8)
+	if _, e := rand.Read(b); e != nil {
+		return 0, fmt.Errorf(Errors.RandomSeed, e.Error())
+	}
+
+	return int64(binary.BigEndian.Uint64(b) >> 1), nil
+}
+
+// NewRunGenerator Make the values of a run from the -seed flag, or else the
+// seed in the answer file, or else a random seed. The values in the answer
+// file, such as a record, are used again unless -seed gives another seed.
+func NewRunGenerator(cfg *Config) (*Generator, error) {
+	aj := cfg.AnswersJson
+	if aj == nil {
+		aj = NewAnswerJson()
+	}
+
+	switch {
+	case cfg.SetFlags["seed"]:
+		if aj.Seed != nil && *aj.Seed == cfg.Seed {
+			return NewGenerator(cfg.Seed, aj.Generated), nil
+		}
+		return NewGenerator(cfg.Seed, nil), nil
+	case aj.Seed != nil:
+		return NewGenerator(*aj.Seed, aj.Generated), nil
+	}
+
+	seed, e := RandomSeed()
+	if e != nil {
+		return nil, e
+	}
+
+	return NewGenerator(seed, aj.Generated), nil
+}
+
+// Funcs The template functions that make values.
+func (g *Generator) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"password":     g.password,
+		"randAlphaNum": g.randAlphaNum,
+		"randInt":      g.randInt,
+		"uuid":         g.uuid,
+	}
+}
+
+// Seed The seed the values are made from.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Values The values made, or given, so far by key.
+func (g *Generator) Values() map[string]string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	values := make(map[string]string, len(g.values))
+	for k, v := range g.values {
+		values[k] = v
+	}
+
+	return values
+}
+
+// Secret Check if the value of a key is a password.
+func (g *Generator) Secret(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.secret[key]
+}
+
+// value Return the value of a key, making it when there is not one yet.
+func (g *Generator) value(fn string, name []string, newValue func() string) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var key string
+	if len(name) > 0 && name[0] != "" {
+		key = name[0]
+	} else {
+		g.counts[fn]++
+		key = fmt.Sprintf("%v#%d", fn, g.counts[fn])
+	}
+
+	if fn == "password" {
+		g.secret[key] = true
+	}
+
+	if v, ok := g.values[key]; ok {
+		return v
+	}
+
+	v := newValue()
+	g.values[key] = v
+
+	return v
+}
+
+// password Make a password of n characters with at least one upper and one
+// lower case letter, a digit and a symbol, such as {{ password 24 "dbPass" }}.
+func (g *Generator) password(n int, name ...string) (string, error) {
+	if n < pwMinLen {
+		return "", fmt.Errorf(Errors.PasswordTooShort, n, pwMinLen)
+	}
+
+	return g.value("password", name, func() string {
+		all := alphaNum + pwSymbols
+		pw := []byte{
+			alphaNum[g.rnd.IntN(26)],
+			alphaNum[26+g.rnd.IntN(26)],
+			alphaNum[52+g.rnd.IntN(10)],
+			pwSymbols[g.rnd.IntN(len(pwSymbols))],
+		}
+		for len(pw) < n {
+			pw = append(pw, all[g.rnd.IntN(len(all))])
+		}
+		g.rnd.Shuffle(len(pw), func(i, j int) {
+			pw[i], pw[j] = pw[j], pw[i]
+		})
+
+		return string(pw)
+	}), nil
+}
+
+// randAlphaNum Make a string of n letters and digits.
+func (g *Generator) randAlphaNum(n int, name ...string) string {
+	return g.value("randAlphaNum", name, func() string {
+		sb := &strings.Builder{}
+		for i := 0; i < n; i++ {
+			sb.WriteByte(alphaNum[g.rnd.IntN(len(alphaNum))])
+		}
+
+		return sb.String()
+	})
+}
+
+// randInt Make a number from min up to, but not including, max, such as
+// {{ randInt 8000 9000 "port" }}.
+func (g *Generator) randInt(min, max int, name ...string) (int, error) {
+	if max <= min {
+		return 0, fmt.Errorf(Errors.RandIntRange, min, max)
+	}
+
+	v := g.value("randInt", name, func() string {
+		return strconv.Itoa(min + g.rnd.IntN(max-min))
+	})
+
+	return strconv.Atoi(v)
+}
+
+// uuid Make a version 4 UUID.
+func (g *Generator) uuid(name ...string) string {
+	return g.value("uuid", name, func() string {
+		b := binary.BigEndian.AppendUint64(nil, g.rnd.Uint64())
+		b = binary.BigEndian.AppendUint64(b, g.rnd.Uint64())
+		b[6] = b[6]&0x0f | 0x40 // version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	})
+}
+
+func init() {
+	// The manifest parser only needs to know the functions exist, Parse uses
+	// the generator of the run.
+	for name, fn := range NewGenerator(0, nil).Funcs() {
+		funcMap[name] = fn
+	}
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestGeneratorSameSeed(tester *testing.T) {
+	text := `{{ uuid }} {{ randAlphaNum 12 }} {{ randInt 1 1000 }} {{ password 16 }}`
+
+	a, e1 := execGenerator(NewGenerator(7, nil), text)
+	b, e2 := execGenerator(NewGenerator(7, nil), text)
+	c, e3 := execGenerator(NewGenerator(8, nil), text)
+
+	if e1 != nil || e2 != nil || e3 != nil {
+		tester.Fatalf("want nil, got: %v, %v, %v", e1, e2, e3)
+	}
+
+	if a != b {
+		tester.Errorf("the same seed made %q and %q", a, b)
+	}
+
+	if a == c {
+		tester.Errorf("seeds 7 and 8 both made %q", a)
+	}
+}
+
+func TestGeneratorValues(runner *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		values map[string]string
+		want   string
+	}{
+		{"namedIsShared", `{{ $a := randAlphaNum 8 "key" }}{{ $b := randAlphaNum 8 "key" }}{{ eq $a $b }}`, nil, "true"},
+		{"unnamedDiffer", `{{ $a := randAlphaNum 8 }}{{ $b := randAlphaNum 8 }}{{ eq $a $b }}`, nil, "false"},
+		{"givenNamed", `{{ uuid "appId" }}`, map[string]string{"appId": "given"}, "given"},
+		{"givenUnnamed", `{{ password 8 }} {{ password 8 }}`, map[string]string{"password#2": "second"}, "second"},
+		{"givenInt", `{{ randInt 1 10 "port" }}`, map[string]string{"port": "8080"}, "8080"},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			got, err := execGenerator(NewGenerator(1, tc.values), tc.text)
+
+			if err != nil {
+				t.Fatalf("want nil, got: %q", err.Error())
+			}
+
+			if !strings.HasSuffix(got, tc.want) {
+				t.Errorf("got %q, want it to end with %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorRecordsValues(tester *testing.T) {
+	gen := NewGenerator(3, map[string]string{"given": "yes"})
+
+	if _, e := execGenerator(gen, `{{ uuid "appId" }}{{ uuid }}{{ randInt 1 9 }}`); e != nil {
+		tester.Fatal(e)
+	}
+
+	got := gen.Values()
+	for _, key := range []string{"appId", "given", "randInt#1", "uuid#1"} {
+		if _, ok := got[key]; !ok {
+			tester.Errorf("missing %v in %v", key, got)
+		}
+	}
+
+	if gen.Seed() != 3 {
+		tester.Errorf("got seed %v, want 3", gen.Seed())
+	}
+}
+
+func TestGeneratorFormats(tester *testing.T) {
+	gen := NewGenerator(11, nil)
+
+	reUuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 50; i++ {
+		if u := gen.uuid(); !reUuid.MatchString(u) {
+			tester.Errorf("%q is not a version 4 UUID", u)
+		}
+
+		pw, _ := gen.password(pwMinLen)
+		if len(pw) != pwMinLen ||
+			!strings.ContainsAny(pw, alphaNum[:26]) ||
+			!strings.ContainsAny(pw, alphaNum[26:52]) ||
+			!strings.ContainsAny(pw, alphaNum[52:]) ||
+			!strings.ContainsAny(pw, pwSymbols) {
+			tester.Errorf("%q does not have one of each kind of character", pw)
+		}
+
+		if n, _ := gen.randInt(5, 7); n < 5 || n >= 7 {
+			tester.Errorf("%v is not in [5, 7)", n)
+		}
+
+		if s := gen.randAlphaNum(10); len(s) != 10 || strings.Trim(s, alphaNum) != "" {
+			tester.Errorf("%q is not 10 letters and digits", s)
+		}
+	}
+}
+
+func TestGeneratorErrors(runner *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+	}{
+		{"passwordTooShort", `{{ password 3 }}`},
+		{"randIntEmptyRange", `{{ randInt 5 5 }}`},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			_, err := execGenerator(NewGenerator(1, nil), tc.text)
+
+			if err == nil {
+				t.Errorf("want an error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewRunGenerator(runner *testing.T) {
+	seed := int64(9)
+	recorded := &AnswersJson{Seed: &seed, Generated: map[string]string{"appId": "recorded"}}
+
+	testCases := []struct {
+		name       string
+		cfg        *Config
+		wantSeed   int64
+		wantValues bool
+	}{
+		{"seedFlag", &Config{Seed: 5, SetFlags: map[string]bool{"seed": true}}, 5, false},
+		{"seedFlagSameAsRecord", &Config{AnswersJson: recorded, Seed: 9, SetFlags: map[string]bool{"seed": true}}, 9, true},
+		{"seedFlagNotRecord", &Config{AnswersJson: recorded, Seed: 5, SetFlags: map[string]bool{"seed": true}}, 5, false},
+		{"recordSeed", &Config{AnswersJson: recorded}, 9, true},
+	}
+
+	for _, tc := range testCases {
+		runner.Run(tc.name, func(t *testing.T) {
+			gen, err := NewRunGenerator(tc.cfg)
+
+			if err != nil {
+				t.Fatalf("want nil, got: %q", err.Error())
+			}
+
+			if gen.Seed() != tc.wantSeed {
+				t.Errorf("got seed %v, want %v", gen.Seed(), tc.wantSeed)
+			}
+
+			if _, got := gen.Values()["appId"]; got != tc.wantValues {
+				t.Errorf("got recorded values %v, want %v", got, tc.wantValues)
+			}
+		})
+	}
+}
+
+func execGenerator(gen *Generator, text string) (string, error) {
+	t, e1 := template.New("test").Funcs(gen.Funcs()).Parse(text)
+	if e1 != nil {
+		return "", e1
+	}
+
+	sb := &strings.Builder{}
+	e2 := t.Execute(sb, nil)
+
+	return sb.String(), e2
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib/log"
+	"os"
+)
+
+// RecordFile Name of the file, placed in the output directory, that records
+// how the output was generated.
+const RecordFile = ".tmpltoapp.json"
+
+// Record How an output directory was generated from a template. It has the
+// placeholders of an answers file, so it can be used as one to generate the
+// output again.
+type Record struct {
+	AppVersion   string            `json:"appVersion"`
+	Generated    map[string]string `json:"generated,omitempty"` // Values made by functions such as uuid, by key.
+	Placeholders tmplVars          `json:"placeholders"`
+	Seed         *int64            `json:"seed,omitempty"` // Seed the generated values were made from.
+	Template     RecordTemplate    `json:"template"`
+}
+
+// RecordTemplate The template an output directory was generated from.
+type RecordTemplate struct {
+	Commit  string `json:"commit,omitempty"`  // Commit of a git template that was checked out.
+	Path    string `json:"path"`              // Location of the template, as given.
+	Ref     string `json:"ref,omitempty"`     // Ref of a git template, as given.
+	Type    string `json:"type"`              // Type of template; zip, git or dir.
+	Version string `json:"version,omitempty"` // Version from the template manifest.
+}
+
+// NewRecord Record the template and answers of the current run.
+func NewRecord(cfg *Config) *Record {
+	r := &Record{
+		AppVersion: cfg.CurrentVersion,
+		Template: RecordTemplate{
+			Path: RedactUrl(cfg.TmplPath),
+			Type: cfg.TmplType,
+		},
+	}
+
+	if cfg.AnswersJson != nil {
+		r.Placeholders = cfg.AnswersJson.Placeholders
+	}
+
+	if cfg.TmplJson != nil {
+		r.Template.Version = cfg.TmplJson.Version
+	}
+
+	if cfg.TmplType == "git" {
+		r.Template.Commit = cfg.TmplCommit
+		r.Template.Ref = cfg.Branch
+	}
+
+	return r
+}
+
+// SetGenerated Keep the values a run made, so the output can be made again.
+// The record is kept with the output, so passwords, and the seed that makes
+// them again, are left out unless withSecrets is true.
+func (r *Record) SetGenerated(gen *Generator, withSecrets bool) {
+	r.Generated = map[string]string{}
+	hasSecrets := false
+
+	for k, v := range gen.Values() {
+		if gen.Secret(k) {
+			hasSecrets = true
+			if !withSecrets {
+				continue
+			}
+		}
+		r.Generated[k] = v
+	}
+
+	if withSecrets || !hasSecrets {
+		seed := gen.Seed()
+		r.Seed = &seed
+	}
+}
+
+// ReadRecord Read the record of how an output directory was generated.
+func ReadRecord(dir string) (*Record, error) {
+	file := dir + PS + RecordFile
+
+	content, e1 := os.ReadFile(file)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.CannotReadAnswerFile, file, e1.Error())
+	}
+
+	r := &Record{}
+	if e := json.Unmarshal(content, r); e != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, file, e.Error())
+	}
+
+	return r, nil
+}
+
+// WriteRecord Save the record in the output directory, only the owner can
+// read it.
+func WriteRecord(dir string, r *Record) error {
+	data, e1 := json.MarshalIndent(r, "", "    ")
+	if e1 != nil {
+		return fmt.Errorf(Errors.encodingJson, RecordFile, e1.Error())
+	}
+
+	file := dir + PS + RecordFile
+	if e := os.WriteFile(file, data, 0600); e != nil {
+		return fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+	}
+
+	log.Infof(Messages.SavedRecord, file)
+
+	return nil
+}
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RegistryFile Name of the file, in the data directory, of the template
+// aliases.
+const RegistryFile = "registry.json"
+
+var (
+	reAliasName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	reTmplType  = regexp.MustCompile(`^(zip|git|dir)?$`)
+)
+
+// Alias A short name for a template, with the ref and answers to use when
+// none are given.
+type Alias struct {
+	Answers string `json:"answers,omitempty"` // Answer file used when there is no -answer-path.
+	Ref     string `json:"ref,omitempty"`     // Ref pinned for the template, used when there is no -ref.
+	Source  string `json:"source"`            // Location of the template.
+	Type    string `json:"type,omitempty"`    // Type of template, used when there is no -tmpl-type.
+}
+
+// Registry The template aliases of a user.
+type Registry struct {
+	Aliases map[string]*Alias `json:"aliases"`
+}
+
+// IsAliasName Check if a name can be used for an alias; letters, digits, "-"
+// and "_", so it is never mistaken for a path or URL.
+func IsAliasName(name string) bool {
+	return reAliasName.MatchString(name)
+}
+
+// LoadRegistry Read the template aliases from a file, there are none when
+// the file does not exist.
+func LoadRegistry(file string) (*Registry, error) {
+	reg := &Registry{Aliases: map[string]*Alias{}}
+
+	content, e1 := os.ReadFile(file)
+	if os.IsNotExist(e1) {
+		return reg, nil
+	}
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.CouldNotReadFile, file, e1.Error())
+	}
+
+	if e := json.Unmarshal(content, reg); e != nil {
+		return nil, fmt.Errorf(Errors.CouldNotDecode, file, e.Error())
+	}
+
+	if reg.Aliases == nil {
+		reg.Aliases = map[string]*Alias{}
+	}
+
+	return reg, nil
+}
+
+// Add an alias, replacing any of the same name.
+func (reg *Registry) Add(name string, alias *Alias) error {
+	if !IsAliasName(name) {
+		return fmt.Errorf(Errors.BadAliasName, name)
+	}
+
+	if alias.Source == "" {
+		return fmt.Errorf(Errors.AliasNoSource, name)
+	}
+
+	if !reTmplType.MatchString(alias.Type) {
+		return fmt.Errorf(Errors.BadTmplType, alias.Type)
+	}
+
+	// Local paths work from any directory.
+	if !IsRemoteLocation(alias.Source) && stdlib.PathExist(alias.Source) {
+		alias.Source, _ = filepath.Abs(alias.Source)
+	}
+
+	if alias.Answers != "" {
+		alias.Answers, _ = filepath.Abs(alias.Answers)
+	}
+
+	reg.Aliases[name] = alias
+
+	return nil
+}
+
+// Names Return the names of the aliases, sorted.
+func (reg *Registry) Names() []string {
+	names := make([]string, 0, len(reg.Aliases))
+	for name := range reg.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Remove an alias.
+func (reg *Registry) Remove(name string) error {
+	if _, ok := reg.Aliases[name]; !ok {
+		return fmt.Errorf(Errors.AliasNotFound, name)
+	}
+
+	delete(reg.Aliases, name)
+
+	return nil
+}
+
+// Save the template aliases to a file.
+func (reg *Registry) Save(file string) error {
+	data, e1 := json.MarshalIndent(reg, "", "    ")
+	if e1 != nil {
+		return fmt.Errorf(Errors.encodingJson, file, e1.Error())
+	}
+
+	if e := writeFileAtomic(file, data, 0644); e != nil {
+		return fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+	}
+
+	log.Dbugf(Messages.SaveData, Redact(string(data)))
+
+	return nil
+}
+
+// resolveAlias Replace a template alias with its location, and use its ref,
+// type and answers for those not given on the command line. A path that
+// exists is used over an alias of the same name.
+func (cfg *Config) resolveAlias(file string) error {
+	if !IsAliasName(cfg.TmplPath) {
+		return nil
+	}
+
+	reg, e1 := LoadRegistry(file)
+	if e1 != nil {
+		return e1
+	}
+
+	alias, ok := reg.Aliases[cfg.TmplPath]
+	if !ok {
+		return nil
+	}
+
+	if stdlib.PathExist(cfg.TmplPath) {
+		log.Logf(Messages.AliasShadowed, cfg.TmplPath)
+		return nil
+	}
+
+	log.Infof(Messages.UsingAlias, RedactUrl(alias.Source), cfg.TmplPath)
+
+	cfg.Alias = cfg.TmplPath
+	cfg.TmplPath = alias.Source
+
+	if alias.Ref != "" && !cfg.SetFlags["ref"] && !cfg.SetFlags["branch"] {
+		cfg.Branch = alias.Ref
+	}
+
+	if alias.Type != "" && !cfg.SetFlags["tmpl-type"] {
+		cfg.TmplType = alias.Type
+	}
+
+	if alias.Answers != "" && cfg.AnswersPath == "" {
+		if !stdlib.PathExist(alias.Answers) {
+			return fmt.Errorf(Errors.AliasAnswers, alias.Answers, cfg.Alias)
+		}
+		cfg.AnswersPath = alias.Answers
+	}
+
+	return nil
+}
+
+// aliasSetting Split an alias setting, such as "alias.web.ref", into the
+// name of the alias and what to set; nothing for its location.
+func aliasSetting(key string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(key, "alias.")
+	if !ok {
+		return "", "", false
+	}
+
+	name, field, _ := strings.Cut(rest, ".")
+
+	return name, field, true
+}
+
+// setAlias Set the location, ref, answers or type of an alias. Setting no
+// location removes the alias.
+func (cfg *Config) setAlias(key, val string) error {
+	name, field, _ := aliasSetting(key)
+	file := cfg.DataDir + PS + RegistryFile
+
+	reg, e1 := LoadRegistry(file)
+	if e1 != nil {
+		return e1
+	}
+
+	alias := &Alias{}
+	if a, ok := reg.Aliases[name]; ok {
+		alias = a
+	}
+
+	switch field {
+	case "":
+		log.Dbugf("setting alias %v = %q", name, val)
+		if val == "" {
+			if e := reg.Remove(name); e != nil {
+				return e
+			}
+			return reg.Save(file)
+		}
+		alias.Source = val
+		break
+	case "answers":
+		log.Dbugf("setting alias %v answers %q", name, val)
+		alias.Answers = val
+		break
+	case "ref":
+		log.Dbugf("setting alias %v ref %q", name, val)
+		alias.Ref = val
+		break
+	case "type":
+		log.Dbugf("setting alias %v type %q", name, val)
+		alias.Type = val
+		break
+	default:
+		return fmt.Errorf(Errors.BadAliasSetting, key)
+	}
+
+	if e := reg.Add(name, alias); e != nil {
+		return e
+	}
+
+	return reg.Save(file)
+}
+
+// getAlias Get the location, ref, answers or type of an alias.
+func (cfg *Config) getAlias(key string) (string, error) {
+	name, field, _ := aliasSetting(key)
+
+	reg, e1 := LoadRegistry(cfg.DataDir + PS + RegistryFile)
+	if e1 != nil {
+		return "", e1
+	}
+
+	alias, ok := reg.Aliases[name]
+	if !ok {
+		return "", fmt.Errorf(Errors.AliasNotFound, name)
+	}
+
+	switch field {
+	case "":
+		return alias.Source, nil
+	case "answers":
+		return alias.Answers, nil
+	case "ref":
+		return alias.Ref, nil
+	case "type":
+		return alias.Type, nil
+	}
+
+	return "", fmt.Errorf(Errors.BadAliasSetting, key)
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistry(tester *testing.T) {
+	file := TmpDir + PS + "registry-test" + PS + RegistryFile
+	_ = os.RemoveAll(filepath.Dir(file))
+	_ = os.MkdirAll(filepath.Dir(file), DirMode)
+
+	reg, err := LoadRegistry(file)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if len(reg.Aliases) != 0 {
+		tester.Errorf("got %v aliases, want none without a file", len(reg.Aliases))
+	}
+
+	var testCases = []struct {
+		name    string
+		alias   string
+		source  string
+		tmpl    string
+		wantErr bool
+	}{
+		{"web", "web", "https://github.com/org/tmpl-go-web.git", "", false},
+		{"api", "api_v2", "https://github.com/org/tmpl-go-api.git", "git", false},
+		{"pathName", "./web", "https://github.com/org/tmpl-go-web.git", "", true},
+		{"urlName", "github.com/org/web", "https://github.com/org/tmpl-go-web.git", "", true},
+		{"noSource", "empty", "", "", true},
+		{"badType", "tar", "https://example.com/web.tar", "tar", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			e := reg.Add(tc.alias, &Alias{Source: tc.source, Type: tc.tmpl})
+
+			if tc.wantErr != (e != nil) {
+				t.Errorf("got error %v, want an error %v", e, tc.wantErr)
+			}
+		})
+	}
+
+	if e := reg.Save(file); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	saved, _ := LoadRegistry(file)
+	if got := saved.Names(); len(got) != 2 || got[0] != "api_v2" || got[1] != "web" {
+		tester.Errorf("got %v, want [api_v2 web]", got)
+	}
+
+	if e := saved.Remove("web"); e != nil {
+		tester.Errorf("got an unexpected err: %s", e)
+	}
+
+	if e := saved.Remove("web"); e == nil {
+		tester.Errorf("got no error, want one for an alias that is gone")
+	}
+}
+
+func TestResolveAlias(tester *testing.T) {
+	dir := TmpDir + PS + "registry-resolve"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+	file := dir + PS + RegistryFile
+	answers, _ := filepath.Abs(dir + PS + "answers.json")
+	_ = os.WriteFile(answers, []byte("{}"), 0644)
+
+	reg, _ := LoadRegistry(file)
+	_ = reg.Add("web", &Alias{Answers: answers, Ref: "2.1.0", Source: "https://github.com/org/tmpl-go-web.git"})
+	_ = reg.Add("site", &Alias{Source: "https://example.com/site.zip", Type: "zip"})
+	// A directory, relative to the tests, with the name of an alias.
+	_ = reg.Add("registry-shadow", &Alias{Source: "https://example.com/shadow.git"})
+	_ = os.MkdirAll("registry-shadow", DirMode)
+	defer os.RemoveAll("registry-shadow")
+	_ = reg.Save(file)
+
+	var testCases = []struct {
+		name        string
+		tmplPath    string
+		setFlags    map[string]bool
+		wantPath    string
+		wantRef     string
+		wantType    string
+		wantAnswers string
+	}{
+		{"alias", "web", nil, "https://github.com/org/tmpl-go-web.git", "2.1.0", "git", answers},
+		{"refGiven", "web", map[string]bool{"ref": true}, "https://github.com/org/tmpl-go-web.git", "main", "git", answers},
+		{"zip", "site", nil, "https://example.com/site.zip", "main", "zip", ""},
+		{"typeGiven", "site", map[string]bool{"tmpl-type": true}, "https://example.com/site.zip", "main", "git", ""},
+		{"notAnAlias", "api", nil, "api", "main", "git", ""},
+		{"path", "./web", nil, "./web", "main", "git", ""},
+		{"pathOverAlias", "registry-shadow", nil, "registry-shadow", "main", "git", ""},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{Branch: "main", SetFlags: tc.setFlags, TmplPath: tc.tmplPath, TmplType: "git"}
+
+			if e := cfg.resolveAlias(file); e != nil {
+				t.Fatalf("got an unexpected err: %s", e)
+			}
+
+			if cfg.TmplPath != tc.wantPath || cfg.Branch != tc.wantRef || cfg.TmplType != tc.wantType || cfg.AnswersPath != tc.wantAnswers {
+				t.Errorf(
+					"got %v %v %v %v, want %v %v %v %v",
+					cfg.TmplPath, cfg.Branch, cfg.TmplType, cfg.AnswersPath,
+					tc.wantPath, tc.wantRef, tc.wantType, tc.wantAnswers,
+				)
+			}
+		})
+	}
+}
+
+func TestSetAlias(tester *testing.T) {
+	dir := TmpDir + PS + "registry-set"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+	cfg := &Config{DataDir: dir, UsrOpts: &UserOptions{}}
+
+	var testCases = []struct {
+		name    string
+		key     string
+		val     string
+		wantErr bool
+	}{
+		{"refBeforeSource", "alias.web.ref", "2.1.0", true},
+		{"source", "alias.web", "https://github.com/org/tmpl-go-web.git", false},
+		{"ref", "alias.web.ref", "2.1.0", false},
+		{"type", "alias.web.type", "zip", false},
+		{"badType", "alias.web.type", "tar", true},
+		{"badField", "alias.web.branch", "main", true},
+		{"badName", "alias.my.web", "https://github.com/org/tmpl-go-web.git", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			e := cfg.set(tc.key, tc.val)
+
+			if tc.wantErr != (e != nil) {
+				t.Errorf("got error %v, want an error %v", e, tc.wantErr)
+			}
+		})
+	}
+
+	if got, _ := cfg.get("alias.web.ref"); got != "2.1.0" {
+		tester.Errorf("got %v, want 2.1.0", got)
+	}
+
+	// An empty location removes the alias.
+	if e := cfg.set("alias.web", ""); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if _, e := cfg.get("alias.web"); e == nil {
+		tester.Errorf("got no error, want one for an alias that is gone")
+	}
+}
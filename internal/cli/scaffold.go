@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// TmplSchema Location of the JSON schema of template.json.
+	TmplSchema = "https://raw.githubusercontent.com/kohirens/tmpltoapp/main/template.schema.json"
+	// TestAnswers Answer file of the test case of a new template.
+	TestAnswers = TestsDir + PS + "default" + PS + testAnswersFile
+)
+
+// skeletonTmplJson What init-template writes to template.json, in the order
+// people expect to read it.
+type skeletonTmplJson struct {
+	Schema       string            `json:"$schema"`
+	Version      string            `json:"version"`
+	Description  string            `json:"description"`
+	Placeholders map[string]string `json:"placeholders"`
+	Excludes     []string          `json:"excludes"`
+	Skip         []string          `json:"skip"`
+}
+
+// skeletonFiles Files of a new template, other than template.json and the
+// test answers.
+var skeletonFiles = []struct {
+	name    string
+	content string
+	sample  bool // Only made when the template does not start from a project.
+}{
+	{
+		name:    "README.md",
+		content: "# {{.appName}}\n\nThis is the README of the project a template makes, edit it to fit yours.\n",
+		sample:  true,
+	},
+	{
+		name:    "example.txt",
+		content: "Hello from {{.appName}}!\n",
+		sample:  true,
+	},
+	{
+		name: "TEMPLATE.md",
+		content: "# Template\n\n" +
+			"Notes for the people that work on this template, it is not part of what\n" +
+			"the template makes.\n\n" +
+			"* Placeholders are listed in template.json.\n" +
+			"* Files and directories listed in " + IgnoreFile + " are left out.\n" +
+			"* Each directory in tests/ is a test case, with the answers.json to render\n" +
+			"  the template with and the expected output. Run \"tmpltoapp test -update\"\n" +
+			"  to save the expected output, and \"tmpltoapp test\" to compare it.\n",
+	},
+	{
+		name: IgnoreFile,
+		content: "# Files and directories that are not part of what the template makes.\n" +
+			"TEMPLATE.md\n" +
+			TestsDir + "/\n",
+	},
+}
+
+// InitTemplate Make a new template in dir, which must not exist or be empty.
+// When from is a project, it is copied and its placeholders seed template.json.
+// Returns the files made, relative to dir.
+func InitTemplate(dir, from string, fec *stdlib.FileExtChecker) ([]string, error) {
+	if files, e := os.ReadDir(dir); e == nil && len(files) > 0 {
+		return nil, fmt.Errorf(Errors.InitTemplateNotEmpty, dir)
+	}
+
+	if e := os.MkdirAll(dir, DirMode); e != nil {
+		return nil, e
+	}
+
+	placeholders := map[string]string{"appName": "Name of the app"}
+	answers := map[string]string{"appName": "Example App"}
+
+	if from != "" {
+		if !stdlib.DirExist(from) {
+			return nil, fmt.Errorf(Errors.pathNotExist, from)
+		}
+
+		if e := copyTree(from, dir); e != nil {
+			return nil, e
+		}
+
+		actions, e1 := GenerateATemplateManifest(dir, fec, []string{})
+		if e1 != nil {
+			return nil, e1
+		}
+
+		if len(actions) > 0 {
+			placeholders = make(map[string]string, len(actions))
+			answers = make(map[string]string, len(actions))
+			for name := range actions {
+				placeholders[name] = "Value for " + name
+				answers[name] = name
+			}
+		}
+	}
+
+	made := []string{TmplManifest}
+
+	tj := skeletonTmplJson{
+		Schema:       TmplSchema,
+		Version:      "0.1.0",
+		Placeholders: placeholders,
+		Excludes:     []string{},
+		Skip:         []string{},
+	}
+	if e := writeJsonFile(dir+PS+TmplManifest, tj); e != nil {
+		return nil, e
+	}
+
+	for _, f := range skeletonFiles {
+		file := dir + PS + f.name
+		// Keep what came from the project.
+		if (f.sample && from != "") || stdlib.PathExist(file) {
+			continue
+		}
+
+		if e := os.WriteFile(file, []byte(f.content), 0644); e != nil {
+			return nil, fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+		}
+		made = append(made, f.name)
+	}
+
+	if e := os.MkdirAll(filepath.Dir(dir+PS+TestAnswers), DirMode); e != nil {
+		return nil, e
+	}
+
+	if e := writeJsonFile(dir+PS+TestAnswers, AnswersJson{Placeholders: answers}); e != nil {
+		return nil, e
+	}
+	made = append(made, filepath.ToSlash(TestAnswers))
+
+	sort.Strings(made)
+
+	return made, nil
+}
+
+// writeJsonFile Save data to a file as indented JSON.
+func writeJsonFile(file string, data interface{}) error {
+	content, e1 := json.MarshalIndent(data, "", "    ")
+	if e1 != nil {
+		return fmt.Errorf(Errors.encodingJson, file, e1.Error())
+	}
+
+	if e := writeFileAtomic(file, append(content, '\n'), 0644); e != nil {
+		return fmt.Errorf(Errors.CouldNotWriteFile, file, e.Error())
+	}
+
+	return nil
+}
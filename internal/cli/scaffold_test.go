@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"github.com/kohirens/stdlib"
+	"os"
+	"testing"
+)
+
+func TestInitTemplate(tester *testing.T) {
+	dir := TmpDir + PS + "init-template-01"
+	_ = os.RemoveAll(dir)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{}, &[]string{})
+
+	got, err := InitTemplate(dir, "", fec)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if len(got) != 6 {
+		tester.Errorf("got %v, want 6 files", got)
+	}
+
+	tj, e2 := ReadTemplateJson(dir + PS + TmplManifest)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if _, ok := tj.Placeholders["appName"]; !ok || tj.Version != "0.1.0" {
+		tester.Errorf("got %+v, want the example placeholder appName", tj)
+	}
+
+	answers, e3 := LoadAnswers(dir + PS + TestAnswers)
+	if e3 != nil || answers.Placeholders["appName"] != "Example App" {
+		tester.Errorf("got %v, %v; want answers for appName", answers, e3)
+	}
+
+	if _, e := InitTemplate(dir, "", fec); e == nil {
+		tester.Errorf("got no error, want one when the directory is not empty")
+	}
+}
+
+func TestInitTemplateFrom(tester *testing.T) {
+	dir := TmpDir + PS + "init-template-02"
+	_ = os.RemoveAll(dir)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{}, &[]string{})
+
+	got, err := InitTemplate(dir, FixtureDir+PS+"template-02", fec)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	for _, f := range got {
+		if f == "README.md" || f == "example.txt" {
+			tester.Errorf("got %v, want no sample files when starting from a project", f)
+		}
+	}
+
+	tj, e2 := ReadTemplateJson(dir + PS + TmplManifest)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if _, ok := tj.Placeholders["var1"]; !ok || len(tj.Placeholders) != 1 {
+		tester.Errorf("got %v, want the placeholder var1 of the project", tj.Placeholders)
+	}
+}
+
+func TestInitTemplateInsideFrom(tester *testing.T) {
+	// Not under TmpDir, go vet would take the copied main.go for source.
+	from := tester.TempDir()
+	_ = os.WriteFile(from+PS+"main.go", []byte("package {{.pkgName}}\n"), 0644)
+	fec, _ := stdlib.NewFileExtChecker(&[]string{}, &[]string{})
+
+	// Like "tmpltoapp init-template -from . tmpl".
+	dir := from + PS + "tmpl"
+	if _, e := InitTemplate(dir, from, fec); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !stdlib.PathExist(dir + PS + "main.go") {
+		tester.Error("got no main.go, want the project copied")
+	}
+
+	if stdlib.PathExist(dir + PS + "tmpl") {
+		tester.Error("got the template copied into itself")
+	}
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/Masterminds/semver/v3"
+	"sort"
+)
+
+// LatestVersion Ref name, and constraint, for the highest version of a template.
+const LatestVersion = "latest"
+
+// SemverTags Return the tags that are semantic versions, the highest first.
+// Pre-releases are left out unless pre is true.
+func SemverTags(tags []string, pre bool) []*semver.Version {
+	versions := make([]*semver.Version, 0, len(tags))
+
+	for _, tag := range tags {
+		v, e := semver.NewVersion(tag)
+		if e != nil || (!pre && v.Prerelease() != "") {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+
+	return versions
+}
+
+// PickVersion Return the tag of the highest version that satisfies a
+// constraint, such as "^2.1", "~1.4" or ">=1.0 <2.0". An empty constraint, or
+// "latest", picks the highest version.
+func PickVersion(tags []string, constraint string, pre bool) (string, error) {
+	if constraint == "" || constraint == LatestVersion {
+		constraint = "*"
+	}
+
+	c, e1 := semver.NewConstraint(constraint)
+	if e1 != nil {
+		return "", fmt.Errorf(Errors.InvalidConstraint, constraint, e1.Error())
+	}
+	c.IncludePrerelease = pre
+
+	for _, v := range SemverTags(tags, pre) {
+		if c.Check(v) {
+			return v.Original(), nil
+		}
+	}
+
+	return "", fmt.Errorf(Errors.NoVersionMatch, constraint)
+}
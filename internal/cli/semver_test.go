@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPickVersion(tester *testing.T) {
+	tags := []string{"1.0.0", "v2.1.0", "2.1.5", "2.2.0-rc.1", "1.4.2", "1.4.9", "1.5.0", "release-x", "3.0.0-alpha"}
+
+	var testCases = []struct {
+		name       string
+		constraint string
+		pre        bool
+		want       string
+		wantErr    bool
+	}{
+		{"latest", "latest", false, "2.1.5", false},
+		{"empty", "", false, "2.1.5", false},
+		{"caret", "^2.1", false, "2.1.5", false},
+		{"tilde", "~1.4", false, "1.4.9", false},
+		{"range", ">=1.0 <2.0", false, "1.5.0", false},
+		{"exact", "2.1.0", false, "v2.1.0", false},
+		{"latestPre", "latest", true, "3.0.0-alpha", false},
+		{"caretPre", "^2.1", true, "2.2.0-rc.1", false},
+		{"noMatch", "^4", false, "", true},
+		{"invalid", "not a version!", false, "", true},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			got, err := PickVersion(tags, tc.constraint, tc.pre)
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSemverTags(tester *testing.T) {
+	tags := []string{"0.1.0", "not-semver", "1.0.0-beta.1", "v0.10.0", "0.9.0"}
+
+	var testCases = []struct {
+		name string
+		pre  bool
+		want string
+	}{
+		{"releases", false, "v0.10.0,0.9.0,0.1.0"},
+		{"preReleases", true, "1.0.0-beta.1,v0.10.0,0.9.0,0.1.0"},
+	}
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, v := range SemverTags(tags, tc.pre) {
+				got = append(got, v.Original())
+			}
+
+			if strings.Join(got, ",") != tc.want {
+				t.Errorf("got %v, want %v", strings.Join(got, ","), tc.want)
+			}
+		})
+	}
+}
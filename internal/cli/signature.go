@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"golang.org/x/crypto/blake2b"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	minisigAlgLegacy    = "Ed" // signature of the file content.
+	minisigAlgPrehashed = "ED" // signature of the BLAKE2b-512 hash of the file content.
+	minisigKeyIdLen     = 8
+	untrustedComment    = "untrusted comment:"
+	trustedComment      = "trusted comment:"
+)
+
+// signatureExts Detached signature files looked for next to a template, in order.
+var signatureExts = []string{".minisig", ".sig"}
+
+// SignatureError A template signature could not be verified.
+type SignatureError struct {
+	File   string // File that was verified.
+	Reason string // Why verification failed.
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf(Errors.SignatureInvalid, e.File, e.Reason)
+}
+
+// publicKey An ed25519 public key with an optional minisign key ID.
+type publicKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+// VerifySignedTemplate Verify the detached signature of a template archive
+// against the trusted public keys.
+//
+// The signature is looked for next to the template location as a minisign
+// "<location>.minisig" file, or a "<location>.sig" file containing a raw (or
+// base64 encoded) ed25519 signature. When client is nil, location is a local
+// path, otherwise it is a URL. When required is false, a template that is
+// missing a signature, or when there are no trusted keys, is NOT verified.
+func VerifySignedTemplate(archive, location string, keys []string, required bool, client Client) error {
+	if len(keys) < 1 {
+		if required {
+			return fmt.Errorf(Errors.NoTrustedKeys)
+		}
+		log.Infof(Messages.NoTrustedKeys, archive)
+		return nil
+	}
+
+	sig, sigExt, e1 := findSignature(location, client)
+	if e1 != nil {
+		return e1
+	}
+
+	if sig == nil {
+		if required {
+			return fmt.Errorf(Errors.SignatureRequired, Redact(location))
+		}
+		log.Infof(Messages.NoSignature, Redact(location))
+		return nil
+	}
+
+	content, e2 := ioutil.ReadFile(archive)
+	if e2 != nil {
+		return e2
+	}
+
+	pubKeys := make([]*publicKey, 0, len(keys))
+	for _, k := range keys {
+		pk, e := parsePublicKey(k)
+		if e != nil {
+			return e
+		}
+		pubKeys = append(pubKeys, pk)
+	}
+
+	var e3 error
+	if sigExt == ".minisig" {
+		e3 = verifyMinisign(content, sig, pubKeys)
+	} else {
+		e3 = verifyEd25519(content, sig, pubKeys)
+	}
+
+	if e3 != nil {
+		return &SignatureError{File: archive, Reason: e3.Error()}
+	}
+
+	log.Infof(Messages.SignatureVerified, archive)
+
+	return nil
+}
+
+// findSignature Return the content of the first detached signature found for
+// a location, along with its extension.
+func findSignature(location string, client Client) ([]byte, string, error) {
+	for _, ext := range signatureExts {
+		if client == nil {
+			if !stdlib.PathExist(location + ext) {
+				continue
+			}
+			sig, e := ioutil.ReadFile(location + ext)
+			return sig, ext, e
+		}
+
+		resp, e1 := client.Get(location + ext)
+		if e1 != nil {
+			return nil, "", e1
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			continue
+		}
+
+		sig, e2 := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		_ = resp.Body.Close()
+
+		return sig, ext, e2
+	}
+
+	return nil, "", nil
+}
+
+// parsePublicKey Decode a base64 ed25519 public key, either raw or in the
+// minisign format. A path to a file containing the key is also accepted.
+func parsePublicKey(key string) (*publicKey, error) {
+	if stdlib.PathExist(key) {
+		content, e := ioutil.ReadFile(key)
+		if e != nil {
+			return nil, e
+		}
+		key = string(content)
+	}
+
+	b, e1 := decodeBase64Line(key)
+	if e1 != nil {
+		return nil, fmt.Errorf(Errors.InvalidPublicKey, key, e1.Error())
+	}
+
+	switch {
+	case len(b) == ed25519.PublicKeySize:
+		return &publicKey{key: b}, nil
+	case len(b) == 2+minisigKeyIdLen+ed25519.PublicKeySize && string(b[:2]) == minisigAlgLegacy:
+		return &publicKey{id: b[2:10], key: b[10:]}, nil
+	}
+
+	return nil, fmt.Errorf(Errors.InvalidPublicKey, key, "unsupported key format")
+}
+
+// verifyEd25519 Verify a raw ed25519 signature of the content.
+func verifyEd25519(content, sig []byte, keys []*publicKey) error {
+	if len(sig) != ed25519.SignatureSize {
+		b, e := decodeBase64Line(string(sig))
+		if e != nil {
+			return e
+		}
+		sig = b
+	}
+
+	if len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("signature is %d bytes, expected %d", len(sig), ed25519.SignatureSize)
+	}
+
+	for _, pk := range keys {
+		if ed25519.Verify(pk.key, content, sig) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no trusted key matched the signature")
+}
+
+// verifyMinisign Verify a minisign signature of the content, including the
+// global signature over the trusted comment.
+func verifyMinisign(content, minisig []byte, keys []*publicKey) error {
+	lines := strings.Split(strings.ReplaceAll(string(minisig), "\r\n", "\n"), "\n")
+	if len(lines) < 4 {
+		return fmt.Errorf("incomplete minisign signature")
+	}
+
+	sig, e1 := decodeBase64Line(lines[1])
+	if e1 != nil {
+		return e1
+	}
+
+	if len(sig) != 2+minisigKeyIdLen+ed25519.SignatureSize {
+		return fmt.Errorf("minisign signature is %d bytes", len(sig))
+	}
+
+	if !strings.HasPrefix(lines[2], trustedComment) {
+		return fmt.Errorf("minisign signature is missing a trusted comment")
+	}
+	comment := strings.TrimSpace(strings.TrimPrefix(lines[2], trustedComment))
+
+	globalSig, e2 := decodeBase64Line(lines[3])
+	if e2 != nil {
+		return e2
+	}
+
+	alg, keyId, fileSig := string(sig[:2]), sig[2:10], sig[10:]
+
+	msg := content
+	switch alg {
+	case minisigAlgLegacy:
+	case minisigAlgPrehashed:
+		h := blake2b.Sum512(content)
+		msg = h[:]
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", alg)
+	}
+
+	for _, pk := range keys {
+		if pk.id != nil && !bytes.Equal(pk.id, keyId) {
+			continue
+		}
+
+		if !ed25519.Verify(pk.key, msg, fileSig) {
+			continue
+		}
+
+		if !ed25519.Verify(pk.key, append(append([]byte{}, fileSig...), comment...), globalSig) {
+			return fmt.Errorf("trusted comment signature does not match")
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no trusted key matched the signature")
+}
+
+// decodeBase64Line Decode the first line of base64 content that is not an
+// untrusted comment.
+func decodeBase64Line(content string) ([]byte, error) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, untrustedComment) {
+			continue
+		}
+		return base64.StdEncoding.DecodeString(line)
+	}
+
+	return nil, fmt.Errorf("no base64 content found")
+}
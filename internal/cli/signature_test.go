@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/blake2b"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// minisignFixture Sign content the way minisign does, returning the public
+// key and the signature file content.
+func minisignFixture(pub ed25519.PublicKey, priv ed25519.PrivateKey, alg string, content []byte) (string, string) {
+	keyId := []byte("12345678")
+	pk := base64.StdEncoding.EncodeToString(append(append([]byte(minisigAlgLegacy), keyId...), pub...))
+
+	msg := content
+	if alg == minisigAlgPrehashed {
+		h := blake2b.Sum512(content)
+		msg = h[:]
+	}
+
+	fileSig := ed25519.Sign(priv, msg)
+	comment := "timestamp:1234"
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, fileSig...), comment...))
+
+	sig := fmt.Sprintf(
+		"untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(alg), keyId...), fileSig...)),
+		comment,
+		base64.StdEncoding.EncodeToString(globalSig),
+	)
+
+	return "untrusted comment: minisign public key\n" + pk, sig
+}
+
+func TestVerifySignedTemplate(tester *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	otherPub, _, _ := ed25519.GenerateKey(nil)
+	content := []byte("fake zip content")
+	rawKey := base64.StdEncoding.EncodeToString(pub)
+	otherKey := base64.StdEncoding.EncodeToString(otherPub)
+	legacyKey, legacySig := minisignFixture(pub, priv, minisigAlgLegacy, content)
+	_, prehashedSig := minisignFixture(pub, priv, minisigAlgPrehashed, content)
+
+	var testCases = []struct {
+		name, ext, sig string
+		keys           []string
+		required       bool
+		wantErr        bool
+	}{
+		{"rawSig", ".sig", string(ed25519.Sign(priv, content)), []string{rawKey}, true, false},
+		{"base64Sig", ".sig", base64.StdEncoding.EncodeToString(ed25519.Sign(priv, content)), []string{otherKey, rawKey}, true, false},
+		{"minisignLegacy", ".minisig", legacySig, []string{legacyKey}, true, false},
+		{"minisignPrehashed", ".minisig", prehashedSig, []string{legacyKey}, true, false},
+		{"minisignRawKey", ".minisig", prehashedSig, []string{rawKey}, true, false},
+		{"untrustedKey", ".sig", string(ed25519.Sign(priv, content)), []string{otherKey}, false, true},
+		{"noSigOptional", "", "", []string{rawKey}, false, false},
+		{"noSigRequired", "", "", []string{rawKey}, true, true},
+		{"noKeysOptional", ".sig", string(ed25519.Sign(priv, content)), nil, false, false},
+		{"noKeysRequired", ".sig", string(ed25519.Sign(priv, content)), nil, true, true},
+	}
+
+	for i, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			archive := fmt.Sprintf("%v%vsigned-%02d.zip", TmpDir, PS, i)
+			_ = ioutil.WriteFile(archive, content, 0644)
+			if tc.ext != "" {
+				_ = ioutil.WriteFile(archive+tc.ext, []byte(tc.sig), 0644)
+			}
+
+			err := VerifySignedTemplate(archive, archive, tc.keys, tc.required, nil)
+
+			if tc.wantErr && err == nil {
+				t.Errorf("did not get expected err")
+			}
+
+			if !tc.wantErr && err != nil {
+				t.Errorf("got an unexpected err: %s", err)
+			}
+		})
+	}
+
+	tester.Run("tamperedIsTyped", func(t *testing.T) {
+		archive := TmpDir + PS + "tampered.zip"
+		_ = ioutil.WriteFile(archive, []byte("tampered content"), 0644)
+		_ = ioutil.WriteFile(archive+".minisig", []byte(legacySig), 0644)
+		defer os.Remove(archive + ".minisig")
+
+		err := VerifySignedTemplate(archive, archive, []string{legacyKey}, false, nil)
+
+		var se *SignatureError
+		if !errors.As(err, &se) {
+			t.Errorf("got %v, want a *SignatureError", err)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"archive/zip"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/stdlib/log"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// snapshotDir Directory, in the cache, of the snapshots of cache entries.
+const snapshotDir = "snapshots"
+
+// SnapshotPath Directory of the snapshot of a cache entry at a version, such
+// as a commit or a checksum.
+func SnapshotPath(cacheDir, name, version string) string {
+	return cacheDir + PS + snapshotDir + PS + name + "@" + version
+}
+
+// Snapshot Copy a directory, without anything Git keeps in .git, to a snapshot
+// that is never changed once it is made. A template is processed from its
+// snapshot, so another process can update the cache entry at the same time.
+func Snapshot(srcDir, dst string) (string, error) {
+	if stdlib.DirExist(dst) {
+		log.Infof(Messages.UsingSnapshot, dst)
+		return dst, nil
+	}
+
+	if e := os.MkdirAll(filepath.Dir(dst), DirMode); e != nil {
+		return "", e
+	}
+
+	tmp, e1 := os.MkdirTemp(filepath.Dir(dst), filepath.Base(dst)+partExt+"-")
+	if e1 != nil {
+		return "", e1
+	}
+	defer os.RemoveAll(tmp)
+
+	if e := copyTree(srcDir, tmp); e != nil {
+		return "", e
+	}
+
+	// Another process may have made the same snapshot first, which is as good.
+	if e := os.Rename(tmp, dst); e != nil && !stdlib.DirExist(dst) {
+		return "", e
+	}
+
+	log.Infof(Messages.MadeSnapshot, dst)
+
+	return dst, nil
+}
+
+// ExtractSnapshot Extract a zip in the cache to a snapshot, of the checksum
+// of the zip, unless it was already. Returns the directory with all the
+// template files.
+func ExtractSnapshot(zipFile, cacheDir string) (string, error) {
+	sum, e1 := FileSha256(zipFile)
+	if e1 != nil {
+		return "", e1
+	}
+
+	dst := SnapshotPath(cacheDir, filepath.Base(zipFile), sum[:cacheHashLen])
+	if !stdlib.DirExist(dst) {
+		return ExtractTo(zipFile, dst)
+	}
+
+	log.Infof(Messages.UsingSnapshot, dst)
+
+	archive, e2 := zip.OpenReader(zipFile)
+	if e2 != nil {
+		return "", e2
+	}
+	defer archive.Close()
+
+	if len(archive.File) == 0 {
+		return dst, nil
+	}
+
+	return filepath.Join(dst, archive.File[0].Name), nil
+}
+
+// copyTree Copy the files, directories and symbolic links of a directory,
+// skipping .git, and the destination when it is inside the directory.
+func copyTree(srcDir, dstDir string) error {
+	absDst, e0 := filepath.Abs(dstDir)
+	if e0 != nil {
+		return e0
+	}
+
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if abs, _ := filepath.Abs(path); abs == absDst {
+				return filepath.SkipDir
+			}
+		}
+
+		if d.Name() == gitDir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			// The .git file of a submodule.
+			return nil
+		}
+
+		rel, _ := filepath.Rel(srcDir, path)
+		dst := filepath.Join(dstDir, rel)
+
+		info, e1 := d.Info()
+		if e1 != nil {
+			return e1
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(dst, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			target, e2 := os.Readlink(path)
+			if e2 != nil {
+				return e2
+			}
+			return os.Symlink(target, dst)
+		case info.Mode().IsRegular():
+			return copyFile(path, dst, info.Mode().Perm())
+		}
+
+		return nil
+	})
+}
+
+// copyFile Copy a file.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, e1 := os.Open(src)
+	if e1 != nil {
+		return e1
+	}
+	defer in.Close()
+
+	out, e2 := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if e2 != nil {
+		return e2
+	}
+
+	if _, e := io.Copy(out, in); e != nil {
+		_ = out.Close()
+		return e
+	}
+
+	return out.Close()
+}
+
+// replaceDir Move a finished directory into place, replacing what is there.
+func replaceDir(src, dst string) error {
+	if e := os.Rename(src, dst); e == nil {
+		return nil
+	}
+
+	if e := os.RemoveAll(dst); e != nil {
+		return e
+	}
+
+	return os.Rename(src, dst)
+}
+
+// writeFileAtomic Write a file to a temporary file that is renamed when done,
+// so readers never see a partial file.
+func writeFileAtomic(file string, data []byte, mode os.FileMode) error {
+	tmp, e1 := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+partExt+"-")
+	if e1 != nil {
+		return e1
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, e := tmp.Write(data); e != nil {
+		_ = tmp.Close()
+		return e
+	}
+
+	if e := tmp.Close(); e != nil {
+		return e
+	}
+
+	if e := os.Chmod(tmp.Name(), mode); e != nil {
+		return e
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"github.com/kohirens/stdlib"
+	"os"
+	"testing"
+)
+
+func TestSnapshot(tester *testing.T) {
+	src := TmpDir + PS + "snapshot-src"
+	cacheDir := TmpDir + PS + "cache-snapshot"
+	_ = os.RemoveAll(src)
+	_ = os.RemoveAll(cacheDir)
+	_ = os.MkdirAll(src+PS+".git", DirMode)
+	_ = os.MkdirAll(src+PS+"dir1", DirMode)
+	_ = os.WriteFile(src+PS+".git"+PS+"HEAD", []byte("ref: refs/heads/main\n"), 0644)
+	_ = os.WriteFile(src+PS+"dir1"+PS+"README.md", []byte("v1"), 0644)
+
+	dst := SnapshotPath(cacheDir, "web-main", "abc123")
+	got, err := Snapshot(src, dst)
+
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if got != dst {
+		tester.Errorf("got %v, want %v", got, dst)
+	}
+
+	if stdlib.PathExist(dst + PS + ".git") {
+		tester.Errorf("got .git in the snapshot, want it skipped")
+	}
+
+	// A snapshot is never changed once it is made.
+	_ = os.WriteFile(src+PS+"dir1"+PS+"README.md", []byte("v2"), 0644)
+	if _, e := Snapshot(src, dst); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	content, _ := os.ReadFile(dst + PS + "dir1" + PS + "README.md")
+	if string(content) != "v1" {
+		tester.Errorf("got %s, want v1", content)
+	}
+
+	// It goes with the entry.
+	if _, e := RemoveCacheEntry(cacheDir, &CacheEntry{Name: "web-main", Type: "git"}); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if stdlib.PathExist(dst) {
+		tester.Errorf("got the snapshot left behind")
+	}
+}
+
+func TestExtractSnapshot(tester *testing.T) {
+	cacheDir := TmpDir + PS + "cache-extract"
+	_ = os.RemoveAll(cacheDir)
+	_ = os.MkdirAll(cacheDir, DirMode)
+
+	zipFile := cacheDir + PS + "001.zip"
+	content, _ := os.ReadFile(FixtureDir + PS + "001.zip")
+	_ = os.WriteFile(zipFile, content, 0644)
+
+	first, err := ExtractSnapshot(zipFile, cacheDir)
+	if err != nil {
+		tester.Fatalf("got an unexpected err: %s", err)
+	}
+
+	if !stdlib.DirExist(first) {
+		tester.Errorf("got no template directory %v", first)
+	}
+
+	// The same zip is extracted once.
+	second, e2 := ExtractSnapshot(zipFile, cacheDir)
+	if e2 != nil {
+		tester.Fatalf("got an unexpected err: %s", e2)
+	}
+
+	if second != first {
+		tester.Errorf("got %v, want %v", second, first)
+	}
+
+	if leftovers, _ := os.ReadDir(cacheDir + PS + snapshotDir); len(leftovers) != 1 {
+		tester.Errorf("got %v entries in the snapshots, want 1", len(leftovers))
+	}
+}
+
+func TestWriteFileAtomic(tester *testing.T) {
+	dir := TmpDir + PS + "atomic"
+	_ = os.RemoveAll(dir)
+	_ = os.MkdirAll(dir, DirMode)
+	file := dir + PS + "index.json"
+	_ = os.WriteFile(file, []byte("old"), 0644)
+
+	if e := writeFileAtomic(file, []byte("new"), 0644); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	content, _ := os.ReadFile(file)
+	if string(content) != "new" {
+		tester.Errorf("got %s, want new", content)
+	}
+
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		tester.Errorf("got %v files, want no temporary files left", len(files))
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 )
 
 const (
@@ -23,7 +24,9 @@ const (
 )
 
 type AnswersJson struct {
-	Placeholders tmplVars `json:"placeholders"`
+	Generated    map[string]string `json:"generated,omitempty"` // Values made by functions such as uuid, by key.
+	Placeholders tmplVars          `json:"placeholders"`
+	Seed         *int64            `json:"seed,omitempty"` // Seed the generated values were made from.
 }
 
 // Client specify the methods reqruied by an HTTP client
@@ -33,10 +36,13 @@ type Client interface {
 }
 
 type TmplJson struct {
-	Excludes     []string    `json:"excludes"`
-	Placeholders tmplVars    `json:"placeholders"`
-	Validation   []validator `json:"validation"`
-	Version      string      `json:"version"`
+	Data         map[string]string `json:"data"` // Data files by name, relative to the template.
+	Description  string            `json:"description"`
+	Excludes     []string          `json:"excludes"`
+	Placeholders tmplVars          `json:"placeholders"`
+	Skip         []string          `json:"skip"`
+	Validation   []validator       `json:"validation"`
+	Version      string            `json:"version"`
 }
 
 type tmplVars map[string]string
@@ -112,62 +118,67 @@ func NewAnswerJson() *AnswersJson {
 }
 
 // Download a template from a URL to a local directory.
+//
+// Failed requests are retried with an increasing delay. A cached download is
+// revalidated with the server using its ETag or Last-Modified date, and an
+// interrupted download is resumed when the server supports range requests.
 func Download(url, dstDir string, client Client) (string, error) {
 	// Save to a unique filename in the cache.
-	dest := strings.ReplaceAll(url, "https://", "")
-	dest = strings.ReplaceAll(dest, "/", "-")
-
-	// Make the HTTP request
-	resp, err1 := client.Get(url)
-	if err1 != nil {
-		return "", err1
-	}
-
-	if resp.StatusCode > 300 || resp.StatusCode < 200 {
-		return "", fmt.Errorf(Errors.TmplPath, resp.Status, resp.StatusCode)
-	}
-
-	zipFile := dstDir + PS + dest
-	// make handle to the file.
-	out, err2 := os.Create(zipFile)
-	if err2 != nil {
-		return "", err2
-	}
-
-	// Write the body to a file
-	_, err3 := io.Copy(out, resp.Body)
-	if err3 != nil {
-		return "", err3
-	}
+	zipFile := dstDir + PS + DownloadName(url)
+	migrateDownload(url, dstDir, zipFile)
+
+	var err error
+	wait := DownloadSettings.Backoff
+	for attempt := 0; attempt <= DownloadSettings.Retries; attempt++ {
+		if attempt > 0 {
+			log.Logf(Messages.DownloadRetry, Redact(url), wait, Redact(err.Error()))
+			time.Sleep(wait)
+			wait *= 2
+		}
 
-	if e := out.Close(); e != nil {
-		return "", e
-	}
+		err = downloadOnce(url, zipFile, client)
+		if err == nil {
+			return zipFile, nil
+		}
 
-	if e := resp.Body.Close(); e != nil {
-		return "", e
+		if _, ok := err.(*retryableError); !ok {
+			return "", err
+		}
 	}
 
-	log.Infof("downloading %v to %v\n", url, dest)
-
-	return zipFile, nil
+	return "", err
 }
 
+// Extract a zip next to it, to a directory of the same name without ".zip".
 func Extract(archivePath string) (string, error) {
+	return ExtractTo(archivePath, strings.ReplaceAll(archivePath, ".zip", ""))
+}
+
+// ExtractTo Extract a zip to a directory, replacing what is there. It is
+// extracted to a temporary directory that is renamed when done, so no one
+// sees a partial extraction. Returns the path of the first file in the zip,
+// which should be the directory with all the template files.
+func ExtractTo(archivePath, dest string) (string, error) {
 	tmplDir := ""
 	zipParentDir := ""
-	dest := strings.ReplaceAll(archivePath, ".zip", "")
 	// Get resource to zip archive.
 	archive, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return tmplDir, fmt.Errorf("could not open archive %q, error: %v", archivePath, err.Error())
 	}
+	defer archive.Close()
 
-	err = os.MkdirAll(dest, DirMode)
+	err = os.MkdirAll(filepath.Dir(dest), DirMode)
 	if err != nil {
 		return tmplDir, fmt.Errorf("could not write dest %q, error: %v", dest, err.Error())
 	}
 
+	tmp, err := os.MkdirTemp(filepath.Dir(dest), filepath.Base(dest)+partExt+"-")
+	if err != nil {
+		return tmplDir, fmt.Errorf("could not write dest %q, error: %v", dest, err.Error())
+	}
+	defer os.RemoveAll(tmp)
+
 	log.Infof("extracting %v to %v\n", archivePath, dest)
 	for _, file := range archive.File {
 		sourceFile, fErr := file.Open()
@@ -175,16 +186,16 @@ func Extract(archivePath string) (string, error) {
 			return tmplDir, fmt.Errorf("failed to Extract archive %q to dest %q, error: %v", archivePath, dest, file.Name)
 		}
 
-		extractionDir := filepath.Join(dest, file.Name)
+		extractionDir := filepath.Join(tmp, file.Name)
 		// trying to figure out the
 		if zipParentDir == "" {
 			// TODO: Document the fact that template archives MUST be zip format and contain all template files in a single directory at the root of the zip.
-			zipParentDir = extractionDir
+			zipParentDir = filepath.Join(dest, file.Name)
 		}
 
 		// Check for ZipSlip (Directory traversal)
-		if !strings.HasPrefix(extractionDir, filepath.Clean(dest)+PS) {
-			return tmplDir, fmt.Errorf("illegal file path: %s", extractionDir)
+		if !strings.HasPrefix(extractionDir, filepath.Clean(tmp)+PS) {
+			return tmplDir, fmt.Errorf("illegal file path: %s", file.Name)
 		}
 
 		if file.FileInfo().IsDir() {
@@ -193,6 +204,10 @@ func Extract(archivePath string) (string, error) {
 				return tmplDir, ferr
 			}
 		} else {
+			if ferr := os.MkdirAll(filepath.Dir(extractionDir), DirMode); ferr != nil {
+				return tmplDir, ferr
+			}
+
 			dh, ferr := os.OpenFile(extractionDir, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
 
 			if ferr != nil {
@@ -216,24 +231,31 @@ func Extract(archivePath string) (string, error) {
 		}
 	}
 
-	err = archive.Close()
+	if e := replaceDir(tmp, dest); e != nil {
+		return tmplDir, fmt.Errorf("could not write dest %q, error: %v", dest, e.Error())
+	}
+
 	tmplDir = zipParentDir
 	log.Dbugf("zipParentDir = %v", zipParentDir)
 
 	return tmplDir, nil
 }
 
-// Parse a file as a Go template.
-func Parse(tplFile, dstDir string, vars tmplVars) error {
+// Parse a file as a Go template. The placeholders and values, such as Data, are
+// at the top level of the template. The generated values, such as uuid, come
+// from gen, or a random seed when it is nil.
+func Parse(tplFile, dstDir string, vars tmplVars, values map[string]interface{}, gen *Generator) error {
 	log.Infof("parsing %v", tplFile)
-	funcMap := template.FuncMap{
-		"title":   strings.Title,
-		"toLower": strings.ToLower,
-		"toUpper": strings.ToUpper,
-	}
 
+	if gen == nil {
+		seed, e := RandomSeed()
+		if e != nil {
+			return e
+		}
+		gen = NewGenerator(seed, nil)
+	}
 	tmplName := filepath.Base(tplFile)
-	parser, err1 := template.New(tmplName).Funcs(funcMap).ParseFiles(tplFile)
+	parser, err1 := template.New(tmplName).Funcs(funcMap).Funcs(gen.Funcs()).ParseFiles(tplFile)
 
 	if err1 != nil {
 		return err1
@@ -252,7 +274,15 @@ func Parse(tplFile, dstDir string, vars tmplVars) error {
 		return err3
 	}
 
-	if e := parser.Execute(file, vars); e != nil {
+	dot := make(map[string]interface{}, len(vars)+len(values))
+	for k, v := range vars {
+		dot[k] = v
+	}
+	for k, v := range values {
+		dot[k] = v
+	}
+
+	if e := parser.Execute(file, dot); e != nil {
 		return e
 	}
 
@@ -263,12 +293,29 @@ func Parse(tplFile, dstDir string, vars tmplVars) error {
 	return nil
 }
 
-// ParseDir Recursively walk a directory parsing all files along the way as Go templates.
-func ParseDir(tplDir, outDir string, vars tmplVars, fec *stdlib.FileExtChecker, excludes []string) (err error) {
+// ParseDir Recursively walk a directory parsing all files along the way as Go
+// templates. What matches a skip pattern, like one in the ignore file, is
+// left out.
+func ParseDir(tplDir, outDir string, vars tmplVars, fec *stdlib.FileExtChecker, excludes, skip []string, values map[string]interface{}, gen *Generator) (err error) {
+	// Every file shares the generated values.
+	if gen == nil {
+		seed, e := RandomSeed()
+		if e != nil {
+			return e
+		}
+		gen = NewGenerator(seed, nil)
+	}
+
 	// Normalize the path separator in these 2 variables before comparing them.
 	normTplDir := strings.ReplaceAll(tplDir, "/", PS)
 	normTplDir = strings.ReplaceAll(normTplDir, "\\", PS)
 
+	ignore, err := ReadIgnoreFile(normTplDir)
+	if err != nil {
+		return
+	}
+	ignore = append(ignore, skip...)
+
 	// Recursively walk the template directory.
 	err = filepath.Walk(normTplDir, func(sourcePath string, fi os.FileInfo, wErr error) (rErr error) {
 		if wErr != nil {
@@ -276,6 +323,18 @@ func ParseDir(tplDir, outDir string, vars tmplVars, fec *stdlib.FileExtChecker,
 			return
 		}
 
+		// Leave out what is not part of the template output.
+		if rel, _ := filepath.Rel(normTplDir, sourcePath); rel != "." {
+			rel = filepath.ToSlash(rel)
+			if rel == IgnoreFile || Ignored(ignore, rel, fi.IsDir()) {
+				log.Infof(Messages.SkipFile, rel)
+				if fi.IsDir() {
+					rErr = filepath.SkipDir
+				}
+				return
+			}
+		}
+
 		log.Infof("\nprocessing: %q", sourcePath)
 
 		// Do not parse directories.
@@ -339,7 +398,7 @@ func ParseDir(tplDir, outDir string, vars tmplVars, fec *stdlib.FileExtChecker,
 			}
 		}
 
-		rErr = Parse(sourcePath, saveDir, vars)
+		rErr = Parse(sourcePath, saveDir, vars, values, gen)
 
 		return
 	})
@@ -378,6 +437,10 @@ func ReadTemplateJson(filePath string) (*TmplJson, error) {
 		return nil, fmt.Errorf("missing the placeholders propery in template.json")
 	}
 
+	if e := CheckReservedNames(&q); e != nil {
+		return nil, e
+	}
+
 	return &q, nil
 }
 
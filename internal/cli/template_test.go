@@ -110,7 +110,7 @@ func TestParseDir2(tester *testing.T) {
 	fileChkr, _ := stdlib.NewFileExtChecker(&[]string{}, &[]string{"tpl"})
 	for _, fxtr := range fixtures {
 		tester.Run(fxtr.name, func(test *testing.T) {
-			err := ParseDir(fxtr.srcDir, fxtr.dstDir, fxtr.vars, fileChkr, []string{})
+			err := ParseDir(fxtr.srcDir, fxtr.dstDir, fxtr.vars, fileChkr, []string{}, nil, nil, nil)
 			isAllGood := fxtr.want(err)
 
 			if !isAllGood {
@@ -158,7 +158,7 @@ func TestParse(tester *testing.T) {
 
 	for _, fxtr := range fixtures {
 		tester.Run(fxtr.name, func(test *testing.T) {
-			err := Parse(fxtr.tplFile, fxtr.appDir, fxtr.vars)
+			err := Parse(fxtr.tplFile, fxtr.appDir, fxtr.vars, nil, nil)
 
 			if !fxtr.gotWhatIWant(err) {
 				test.Error(fxtr.failMsg)
@@ -187,7 +187,7 @@ func TestParseDir(tester *testing.T) {
 	tester.Run(fxtr.name, func(test *testing.T) {
 		fec, _ := stdlib.NewFileExtChecker(nil, &[]string{"md", "yml"})
 
-		err := ParseDir(fxtr.tmplPath, fxtr.outPath, fxtr.tplVars, fec, nil)
+		err := ParseDir(fxtr.tmplPath, fxtr.outPath, fxtr.tplVars, fec, nil, nil, nil, nil)
 
 		if err != nil {
 			test.Errorf("got an error %q", err.Error())
@@ -207,6 +207,26 @@ func TestParseDir(tester *testing.T) {
 	})
 }
 
+func TestParseDirSkip(tester *testing.T) {
+	fixturePath, _ := filepath.Abs(FixtureDir + PS + "parse-dir-01")
+	outPath, _ := filepath.Abs(TmpDir + PS + "parse-dir-skip")
+	_ = os.RemoveAll(outPath)
+	fec, _ := stdlib.NewFileExtChecker(nil, &[]string{"md", "yml"})
+
+	err := ParseDir(fixturePath, outPath, tmplVars{"APP_NAME": "SolarPolar"}, fec, nil, []string{"dir1/"}, nil, nil)
+	if err != nil {
+		tester.Fatalf("got an error %q", err.Error())
+	}
+
+	if stdlib.PathExist(outPath + PS + "dir1") {
+		tester.Error("got dir1 in the output, want it skipped")
+	}
+
+	if !stdlib.PathExist(outPath + PS + "docker-compose.yml") {
+		tester.Error("got no docker-compose.yml, want only dir1 skipped")
+	}
+}
+
 func TestReadTemplateJson(tester *testing.T) {
 	fixturePath1, _ := filepath.Abs(FixtureDir + "/template-03")
 
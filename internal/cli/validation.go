@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"regexp"
 )
 
@@ -11,6 +12,31 @@ type validator struct {
 	message    string
 }
 
+// validatorJson A validator as it is in a template.json.
+type validatorJson struct {
+	Expression string   `json:"expression,omitempty"`
+	Fields     []string `json:"fields"`
+	Message    string   `json:"message,omitempty"`
+	Rule       string   `json:"rule"`
+}
+
+// UnmarshalJSON Read a validator from a template.json.
+func (v *validator) UnmarshalJSON(data []byte) error {
+	vj := validatorJson{}
+	if e := json.Unmarshal(data, &vj); e != nil {
+		return e
+	}
+
+	v.expression, v.fields, v.message, v.rule = vj.Expression, vj.Fields, vj.Message, vj.Rule
+
+	return nil
+}
+
+// MarshalJSON Write a validator as it is in a template.json.
+func (v validator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(validatorJson{Expression: v.expression, Fields: v.fields, Message: v.message, Rule: v.rule})
+}
+
 // findValidator locate the validator for a placeholder
 func findValidator(placeholder string, validators []validator) (validator, bool) {
 	var val validator
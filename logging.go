@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+)
 
 const (
 	verboseLvlLog  = 0
@@ -35,10 +38,10 @@ func logf(message string, vars ...interface{}) {
 }
 
 // Show additional logging based on the verbosity level. Prints a newline after every message.
+// Credentials are redacted from every message.
 func verboseF(lvl int, message string, a ...interface{}) {
 	if verbosityLevel >= lvl {
-		fmt.Printf(message, a...)
-		fmt.Println()
+		fmt.Println(cli.Redact(fmt.Sprintf(message, a...)))
 	}
 }
 
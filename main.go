@@ -7,7 +7,6 @@ import (
 	"github.com/kohirens/stdlib"
 	"github.com/kohirens/tmpltoapp/internal/cli"
 	"log"
-	"net/http"
 	"os"
 )
 
@@ -38,7 +37,7 @@ func main() {
 	defer func() {
 		if mainErr != nil {
 			logf(cli.Errors.FatalHeader)
-			log.Fatalln(mainErr.Error())
+			log.Fatalln(cli.Redact(mainErr.Error()))
 		}
 		os.Exit(0)
 	}()
@@ -48,6 +47,10 @@ func main() {
 		return
 	}
 
+	if verbosityLevel >= verboseLvlInfo {
+		cli.DownloadSettings.Progress = os.Stdout
+	}
+
 	mainErr = appConfig.Setup(AppName, cli.PS, cli.DirMode)
 	if mainErr != nil {
 		return
@@ -63,73 +66,43 @@ func main() {
 
 	// process sub-commands
 	switch appConfig.SubCmd {
+	case cli.CmdAlias:
+		mainErr = runAlias(appConfig, os.Stdout)
+		return
+	case cli.CmdAnswers:
+		mainErr = runAnswers(appConfig, os.Stdout)
+		return
+	case cli.CmdCache:
+		mainErr = runCache(appConfig, os.Stdout)
+		return
 	case cli.CmdConfig:
 		// store or get the key and return
-		mainErr = cli.UpdateUserSettings(appConfig, cli.DirMode)
+		mainErr = cli.UpdateUserSettings(appConfig, cli.ConfigMode)
+		return
+	case cli.CmdInitTemplate:
+		mainErr = runInitTemplate(appConfig, os.Stdout)
+		return
+	case cli.CmdInspect:
+		mainErr = runInspect(appConfig, os.Stdout)
 		return
 	case cli.CmdManifest:
 		// store or get the key and return
 		fec, _ := stdlib.NewFileExtChecker(&[]string{".empty", "exe", "gif", "jpg", "mp3", "pdf", "png", "tiff", "wmv"}, &[]string{})
 		_, mainErr = cli.GenerateATemplateManifest(appConfig.SubCmdManifest.Path, fec, []string{})
 		return
+	case cli.CmdMirror:
+		mainErr = runMirror(appConfig, os.Stdout)
+		return
+	case cli.CmdTest:
+		mainErr = runTest(appConfig, os.Stdout)
+		return
+	case cli.CmdVersions:
+		mainErr = printVersions(appConfig, os.Stdout)
+		return
 	}
 
-	if appConfig.TmplType == "zip" {
-		var zipFile string
-		var iErr error
-		zipFile = appConfig.TmplPath
-		if appConfig.TmplLocation == "remote" {
-			client := http.Client{}
-			zipFile, iErr = cli.Download(appConfig.TmplPath, appConfig.UsrOpts.CacheDir, &client)
-			if iErr != nil {
-				mainErr = iErr
-				return
-			}
-		}
-
-		appConfig.Tmpl, iErr = cli.Extract(zipFile)
-		if iErr != nil {
-			mainErr = iErr
-			return
-		}
-	}
-
-	if appConfig.TmplType == "git" {
-		var repo, commitHash string
-		var err2 error
-
-		if appConfig.Branch == "latest" {
-			latestTag, e3 := getLatestTag(appConfig.TmplPath)
-			// This error is informative, but not worth stopping the program.
-			logf(e3.Error())
-			if latestTag != "" {
-				appConfig.Branch = latestTag
-			}
-		}
-
-		// Determine the cache location
-		repoDir := appConfig.UsrOpts.CacheDir + cli.PS + getRepoDir(appConfig.TmplPath, appConfig.Branch)
-		infof(cli.Messages.OutRepoDir, repoDir)
-
-		// Do a pull when the repo already exists. This will fail if it downloaded a zip.
-		if stdlib.DirExist(repoDir + cli.PS + gitConfDir) {
-			infof(cli.Messages.UsingCache, repoDir)
-			repo, commitHash, err2 = gitCheckout(repoDir, appConfig.Branch)
-		} else {
-			infof(cli.Messages.CloningToCache, repoDir)
-			repo, commitHash, err2 = gitClone(appConfig.TmplPath, repoDir, appConfig.Branch)
-		}
-
-		infof(cli.Messages.RepoInfo, repo, commitHash)
-		if err2 != nil {
-			mainErr = err2
-			return
-		}
-		appConfig.Tmpl = repo
-	}
-
-	if !stdlib.DirExist(appConfig.Tmpl) {
-		mainErr = fmt.Errorf(cli.Errors.InvalidTmplDir, appConfig.Tmpl)
+	mainErr = resolveTemplate(appConfig)
+	if mainErr != nil {
 		return
 	}
 
@@ -138,15 +111,16 @@ func main() {
 		mainErr = fmt.Errorf(cli.Errors.CannotInitFileChecker, err1.Error())
 	}
 
-	// Require template directories to have a specific file in order to be processed to prevent processing directories unintentionally.
-	tmplManifestFile := appConfig.Tmpl + cli.PS + cli.TmplManifest
-	tmplManifest, errX := cli.ReadTemplateJson(tmplManifestFile)
-	if errX != nil {
-		mainErr = fmt.Errorf(cli.Errors.MissingTmplJson, cli.TmplManifest, tmplManifestFile, errX.Error())
+	data, err2 := cli.LoadData(appConfig.Tmpl, appConfig.TmplJson.Data, appConfig.DataFiles)
+	if err2 != nil {
+		mainErr = err2
 		return
 	}
+	values := map[string]interface{}{
+		cli.DataKey: data,
+		cli.MetaKey: cli.NewMeta(appConfig, appConfig.OutPath, cli.Clock()),
+	}
 
-	appConfig.TmplJson = tmplManifest
 	appConfig.AnswersJson = cli.NewAnswerJson()
 
 	if stdlib.PathExist(appConfig.AnswersPath) {
@@ -156,6 +130,11 @@ func main() {
 		}
 	}
 
+	if appConfig.Batch != "" {
+		mainErr = runBatch(appConfig, fec, values, os.Stdout)
+		return
+	}
+
 	// Checks for any missing placeholder values waits for their input from the CLI.
 	if e := cli.GetPlaceholderInput(appConfig.TmplJson, &appConfig.AnswersJson.Placeholders, os.Stdin, appConfig.DefaultVal); e != nil {
 		mainErr = fmt.Errorf(cli.Errors.GettingAnswers, e.Error())
@@ -163,5 +142,20 @@ func main() {
 
 	cli.ShowAllPlaceholderValues(appConfig.TmplJson, &appConfig.AnswersJson.Placeholders)
 
-	mainErr = cli.ParseDir(appConfig.Tmpl, appConfig.OutPath, appConfig.AnswersJson.Placeholders, fec, tmplManifest.Excludes)
+	gen, err3 := cli.NewRunGenerator(appConfig)
+	if err3 != nil {
+		mainErr = err3
+		return
+	}
+
+	mainErr = cli.ParseDir(appConfig.Tmpl, appConfig.OutPath, appConfig.AnswersJson.Placeholders, fec, appConfig.TmplJson.Excludes, appConfig.TmplJson.Skip, values, gen)
+	if mainErr != nil {
+		return
+	}
+
+	// Keep the generated values so the output can be made again the same.
+	r := cli.NewRecord(appConfig)
+	r.SetGenerated(gen, appConfig.RecordSecrets)
+
+	mainErr = cli.WriteRecord(appConfig.OutPath, r)
 }
@@ -71,6 +71,16 @@ func TestCallingMain(tester *testing.T) {
 				"-tmpl-type", "dir",
 			},
 		},
+		{
+			"lfsPointerTemplate",
+			1,
+			[]string{
+				"-answer-path", FixtureDir + cli.PS + "answers-parse-dir-02.json",
+				"-tmpl-path", FixtureDir + cli.PS + "lfs-01",
+				"-out-path", TmpDir + cli.PS + "app-lfs-01",
+				"-tmpl-type", "dir",
+			},
+		},
 		{
 			"downloadZipTemplate",
 			0,
@@ -92,8 +102,29 @@ func TestCallingMain(tester *testing.T) {
 				"-branch", "refs/tags/0.3.0",
 			},
 		},
+		{"alias0", 0, []string{"alias", "-help"}},
+		{"alias1", 1, []string{"alias", "add", "web"}},
+		{"aliasEmptyArg", 1, []string{"alias", ""}},
+		{"cache0", 0, []string{"cache", "-help"}},
+		{"cache1", 1, []string{"cache", "purge"}},
+		{"cacheEmptyArg", 1, []string{"cache", ""}},
+		{"answers0", 0, []string{"answers", "-help"}},
+		{"answers1", 1, []string{"answers", "make", "web"}},
+		{"answersEmptyArg", 1, []string{"answers", ""}},
+		{"initTemplate0", 0, []string{"init-template", "-help"}},
+		{"initTemplate1", 1, []string{"init-template"}},
+		{"inspect0", 0, []string{"inspect", "-help"}},
+		{"inspect1", 1, []string{"inspect", "-format", "yaml", "web"}},
 		{"manifest0", 0, []string{"manifest", "-h"}},
 		{"manifest0", 1, []string{"manifest"}},
+		{"mirror0", 0, []string{"mirror", "-help"}},
+		{"mirror1", 1, []string{"mirror", "export"}},
+		{"mirrorEmptyArg", 1, []string{"mirror", ""}},
+		{"mirror2", 1, []string{"mirror", "sync", "file.zip"}},
+		{"test0", 0, []string{"test", "-help"}},
+		{"test1", 1, []string{"test"}},
+		{"versions0", 0, []string{"versions", "-h"}},
+		{"versions1", 1, []string{"versions"}},
 	}
 
 	for _, tc := range tests {
@@ -182,6 +213,7 @@ func TestSubCmdConfigSuccess(tester *testing.T) {
 	}{
 		{"help", 0, []string{cli.CmdConfig, "-help"}, "usage: config"},
 		{"getCache", 0, []string{cli.CmdConfig, "get", "CacheDir"}, "tmp"},
+		{"getPolicy", 0, []string{cli.CmdConfig, "get", "Policy"}, "AllowList"},
 	}
 
 	for _, tc := range tests {
@@ -222,6 +254,8 @@ func TestSetUserOptions(tester *testing.T) {
 			[]string{cli.CmdConfig, "set", "ExcludeFileExtensions", "md,txt"},
 			`"ExcludeFileExtensions":["md","txt"]`,
 		},
+		{"setOffline", 0, []string{cli.CmdConfig, "set", "Offline", "true"}, `"Offline":true`},
+		{"setOfflineInvalid", 1, []string{cli.CmdConfig, "set", "Offline", "yes please"}, `"Offline":true`},
 	}
 
 	for _, tc := range tests {
@@ -344,3 +378,142 @@ func TestFirstTimeRun(tester *testing.T) {
 		})
 	}
 }
+
+// Use a git template at a commit, and record the commit in the output.
+func TestGitTemplateRef(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+
+	var tests = []struct {
+		name       string
+		ref        string
+		wantCommit string
+	}{
+		{"abbreviatedHash", "b437757", "b437757d8da54ada2e622996af0911ac5697242b"},
+		{"branch", "main", "b437757d8da54ada2e622996af0911ac5697242b"},
+	}
+
+	for _, tc := range tests {
+		tester.Run(tc.name, func(t *testing.T) {
+			outPath := TmpDir + test.PS + "processed" + test.PS + fixture + "-" + tc.name
+			args := []string{
+				"-answer-path", FixtureDir + test.PS + fixture + "-answers.json",
+				"-tmpl-path", test.SetupARepository(fixture, TmpDir+test.PS+"remotes", FixtureDir, test.PS),
+				"-out-path", outPath,
+				"-ref", tc.ref,
+			}
+
+			cmd := runMain(tester.Name(), args)
+
+			_, _ = test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+			if got := cmd.ProcessState.ExitCode(); got != 0 {
+				t.Fatalf("got %q, want %q", got, 0)
+			}
+
+			record, e := cli.ReadRecord(outPath)
+			if e != nil {
+				t.Fatal(e)
+			}
+
+			if record.Template.Commit != tc.wantCommit {
+				t.Errorf("got %v, want %v", record.Template.Commit, tc.wantCommit)
+			}
+
+			if record.Placeholders["appName"] != "Repo 07" {
+				t.Errorf("record is missing the placeholders, got %v", record.Placeholders)
+			}
+		})
+	}
+}
+
+func TestOffline(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+	tmplPath := test.SetupARepository(fixture, TmpDir+test.PS+"offline-remotes", FixtureDir, test.PS)
+
+	// In order, the cache is empty until the online run.
+	var tests = []struct {
+		name     string
+		offline  bool
+		ref      string
+		wantCode int
+		wantOut  string
+	}{
+		{"notCached", true, "main", 1, "cached refs: none"},
+		{"online", false, "main", 0, ""},
+		{"cached", true, "main", 0, ""},
+		{"refNotCached", true, "b437757", 1, "cached refs: main"},
+		{"flagWinsOverSetting", false, "b437757", 0, ""},
+	}
+
+	// The Offline setting is on, only -offline=false turns it off.
+	setOffline := func(v string) {
+		cmd := runMain(tester.Name(), []string{cli.CmdConfig, "set", "Offline", v})
+		if out, e := cmd.CombinedOutput(); e != nil {
+			tester.Fatalf("could not set Offline to %v: %s", v, out)
+		}
+	}
+	setOffline("true")
+	defer setOffline("false")
+
+	for _, tc := range tests {
+		tester.Run(tc.name, func(t *testing.T) {
+			args := []string{
+				"-answer-path", FixtureDir + test.PS + fixture + "-answers.json",
+				"-tmpl-path", tmplPath,
+				"-out-path", TmpDir + test.PS + "processed" + test.PS + fixture + "-offline-" + tc.name,
+				"-ref", tc.ref,
+			}
+			if tc.offline {
+				args = append([]string{"-offline"}, args...)
+			} else {
+				args = append([]string{"-offline=false"}, args...)
+			}
+
+			cmd := runMain(tester.Name(), args)
+
+			out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+			if got := cmd.ProcessState.ExitCode(); got != tc.wantCode {
+				t.Errorf("got %q, want %q", got, tc.wantCode)
+			}
+
+			if !bytes.Contains(out, []byte(tc.wantOut)) {
+				t.Errorf("got %s, want it to contain %q", out, tc.wantOut)
+			}
+		})
+	}
+}
+
+func TestConcurrentRuns(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+	tmplPath := test.SetupARepository(fixture, TmpDir+test.PS+"concurrent-remotes", FixtureDir, test.PS)
+
+	// Both runs share one cache entry; each must get the whole template.
+	cmds := make([]*exec.Cmd, 2)
+	outs := make([]bytes.Buffer, 2)
+	for i := range cmds {
+		cmds[i] = runMain(tester.Name(), []string{
+			"-answer-path", FixtureDir + test.PS + fixture + "-answers.json",
+			"-tmpl-path", tmplPath,
+			"-out-path", fmt.Sprintf("%v%vprocessed%v%v-concurrent-%d", TmpDir, test.PS, test.PS, fixture, i),
+			"-ref", "main",
+		})
+		cmds[i].Stdout = &outs[i]
+		cmds[i].Stderr = &outs[i]
+
+		if e := cmds[i].Start(); e != nil {
+			tester.Fatal(e)
+		}
+	}
+
+	for i, cmd := range cmds {
+		_ = cmd.Wait()
+
+		if got := cmd.ProcessState.ExitCode(); got != 0 {
+			tester.Errorf("run %d got %v, want 0: %s", i, got, outs[i].String())
+		}
+	}
+}
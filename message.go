@@ -1,13 +1,32 @@
 package main
 
 var usageMsgs = map[string]string{
-	"answer-path": "Path to a JSON file containing the values for placeholders (which are the keys) defined by a template.",
-	"branch":      "Branch of the template to clone when tmplType=git.",
-	"default-val": "Set a default value for any un-set placeholders and skip asking for input from the command line, and useful for un-attended automation.",
-	"help":        "(or -h) Prints usage information and exit 0.",
-	"out-path":    "Path to output the new project.",
-	"tmpl-path":   "URL to a zip or a local path to a directory.",
-	"tmpl-type":   "Can be of git|zip.",
-	"verbosity":   "Set the level of information printed when running.",
-	"version":     "Print build version information and exit 0.",
+	"answer-path":        "Path to a JSON file containing the values for placeholders (which are the keys) defined by a template.",
+	"batch":              "CSV or JSONL data set to run the template with once for each row, the row is the answers; -out-path is then a pattern such as \"out/{{.clientId}}\".",
+	"branch":             "Deprecated, same as -ref.",
+	"continue-on-error":  "Keep going after a row of a batch fails, and print a summary at the end.",
+	"data":               "Data file, JSON, YAML or CSV, as name=path; it is .Data.<name> in the template and replaces a data file of template.json with the same name. Can be given more than once.",
+	"default-val":        "Set a default value for any un-set placeholders and skip asking for input from the command line, and useful for un-attended automation.",
+	"format":             "Print as text or json.",
+	"from":               "Existing project to make the template from.",
+	"help":               "(or -h) Prints usage information and exit 0.",
+	"max-size":           "Remove the least recently used templates until the cache is no bigger than this, such as 500MB or 2GB.",
+	"merge":              "Update the answer file when it exists; add missing placeholders and flag obsolete ones.",
+	"o":                  "Answer file to write.",
+	"older-than":         "Remove templates not used for longer than this, such as 30d or 12h.",
+	"offline":            "Only use templates from the cache, nothing is downloaded or fetched.",
+	"out-path":           "Path to output the new project.",
+	"pre":                "Include pre-release versions when picking the version of a template.",
+	"record-secrets":     "Keep the passwords made, and the seed, in the .tmpltoapp.json record, so they are made the same again from it.",
+	"recurse-submodules": "Also get the submodules of a git template, recursively.",
+	"ref":                "Branch, tag, full ref (refs/...) or full or abbreviated commit hash of the template to use when tmplType=git, or \"latest\" for the highest version.",
+	"require-signature":  "Fail when the signature of a template cannot be verified, see config TrustedKeys for zip templates; git templates must have a signed tag or commit.",
+	"seed":               "Seed for the values made by functions such as uuid and password, the same seed makes the same values; a run without one records a random seed.",
+	"sha256":             "Expected SHA-256 checksum of a zip template, the template is not used when it does not match.",
+	"tmpl-path":          "URL to a zip or a local path to a directory.",
+	"tmpl-type":          "Can be of git|zip.",
+	"tmpl-version":       "Semantic version constraint, such as \"^2.1\", \"~1.4\" or \">=1.0 <2.0\", for the tag of a git template to use; overrides -ref.",
+	"update":             "Save the output of each test case as what it expects.",
+	"verbosity":          "Set the level of information printed when running.",
+	"version":            "Print build version information and exit 0.",
 }
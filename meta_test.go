@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"testing"
+)
+
+func TestMeta(tester *testing.T) {
+	outDir := TmpDir + cli.PS + "app-meta-01"
+	_ = os.RemoveAll(outDir)
+
+	cmd := runMain(tester.Name(), []string{
+		"-default-val", "Web",
+		"-tmpl-path", FixtureDir + cli.PS + "meta-01",
+		"-out-path", outDir,
+		"-tmpl-type", "dir",
+	})
+	// Make the output the same every time.
+	cmd.Env = append(cmd.Env, "SOURCE_DATE_EPOCH=946684800")
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	got, _ := os.ReadFile(outDir + cli.PS + "README.md")
+	want := "# Web\n\nMade 2000-01-01 in app-meta-01, from version 1.4.0 of a dir template.\n\nCopyright 2000\n"
+	if string(got) != want {
+		tester.Errorf("got %q, want %q", got, want)
+	}
+}
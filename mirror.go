@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+	"os"
+)
+
+// runMirror Run the mirror sub-command; export or import.
+func runMirror(cfg *cli.Config, w io.Writer) error {
+	switch cfg.SubCmdMirror.Method {
+	case "export":
+		return mirrorExport(cfg, w)
+	case "import":
+		return mirrorImport(cfg, w)
+	}
+
+	return fmt.Errorf(cli.Errors.BadMirrorCmd, cfg.SubCmdMirror.Method)
+}
+
+// mirrorExport Pack the templates in the cache, or only those of the sources
+// given, into a mirror archive.
+func mirrorExport(cfg *cli.Config, w io.Writer) error {
+	cacheDir := cfg.UsrOpts.CacheDir
+
+	entries, e1 := mirrorEntries(cacheDir, cfg.SubCmdMirror.Sources)
+	if e1 != nil {
+		return e1
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf(cli.Errors.MirrorEmpty)
+	}
+
+	backend, e2 := newGitBackend(cfg.UsrOpts.GitBackend)
+	if e2 != nil {
+		return e2
+	}
+	git = backend
+
+	tmp, e3 := os.MkdirTemp(cacheDir, "mirror-")
+	if e3 != nil {
+		return e3
+	}
+	defer os.RemoveAll(tmp)
+
+	bundles := map[string]string{}
+	for _, ce := range entries {
+		if ce.Type != "git" {
+			continue
+		}
+
+		bundle := tmp + cli.PS + ce.Name + cli.BundleExt
+		if e := bundleCacheEntry(cacheDir, ce, bundle); e != nil {
+			return e
+		}
+		bundles[ce.Name] = bundle
+	}
+
+	if e := cli.ExportMirror(cfg.SubCmdMirror.File, cacheDir, entries, bundles); e != nil {
+		return e
+	}
+
+	for _, ce := range entries {
+		_, _ = fmt.Fprintf(w, "exported %v %v\n", ce.Source, ce.Ref)
+	}
+
+	return nil
+}
+
+// mirrorImport Put the templates in a mirror archive into the cache, they
+// are then used without going to the network.
+func mirrorImport(cfg *cli.Config, w io.Writer) error {
+	cacheDir := cfg.UsrOpts.CacheDir
+
+	if e := os.MkdirAll(cacheDir, cli.DirMode); e != nil {
+		return e
+	}
+
+	backend, e1 := newGitBackend(cfg.UsrOpts.GitBackend)
+	if e1 != nil {
+		return e1
+	}
+	git = backend
+
+	tmp, e2 := os.MkdirTemp(cacheDir, "mirror-")
+	if e2 != nil {
+		return e2
+	}
+	defer os.RemoveAll(tmp)
+
+	m, e3 := cli.ReadMirror(cfg.SubCmdMirror.File, tmp)
+	if e3 != nil {
+		return e3
+	}
+
+	for _, ce := range m.Entries {
+		if e := importCacheEntry(cacheDir, tmp, ce); e != nil {
+			return e
+		}
+		_, _ = fmt.Fprintf(w, "imported %v %v\n", ce.Source, ce.Ref)
+	}
+
+	return nil
+}
+
+// bundleCacheEntry Make a bundle of the clone of a git template in the cache.
+func bundleCacheEntry(cacheDir string, ce *cli.CacheEntry, bundle string) error {
+	unlock, e1 := cli.LockCacheEntry(cacheDir, ce.Name)
+	if e1 != nil {
+		return e1
+	}
+	defer unlock()
+
+	if e := git.Bundle(cacheDir+cli.PS+ce.Name, bundle); e != nil {
+		return fmt.Errorf(cli.Errors.GitBundle, ce.Source, e.Error())
+	}
+
+	return nil
+}
+
+// importCacheEntry Put a template from an extracted mirror into the cache,
+// replacing the entry when there is one. A git template is cloned from its
+// bundle, with the origin set back to where it came from, next to the entry
+// it replaces so that entry is kept when the clone fails.
+func importCacheEntry(cacheDir, dir string, ce *cli.CacheEntry) error {
+	unlock, e1 := cli.LockCacheEntry(cacheDir, ce.Name)
+	if e1 != nil {
+		return e1
+	}
+	defer unlock()
+
+	switch ce.Type {
+	case "git":
+		repoDir := cacheDir + cli.PS + ce.Name
+		bundle := dir + cli.PS + ce.Name + cli.BundleExt
+
+		clone, e2 := os.MkdirTemp(dir, ce.Name+"-")
+		if e2 != nil {
+			return e2
+		}
+
+		if e := git.Clone(bundle, clone, "", 0); e != nil {
+			return fmt.Errorf(cli.Errors.Cloning, bundle, e.Error())
+		}
+
+		if ce.Commit != "" {
+			if e := git.Checkout(clone, ce.Commit); e != nil {
+				return fmt.Errorf(cli.Errors.GitCheckoutFailed, e.Error())
+			}
+		}
+
+		if e := git.SetRemoteUrl(clone, ce.Source); e != nil {
+			return e
+		}
+
+		if e := os.RemoveAll(repoDir); e != nil {
+			return e
+		}
+
+		if e := os.Rename(clone, repoDir); e != nil {
+			return e
+		}
+	case "zip":
+		if e := cli.ImportZip(dir, cacheDir, ce); e != nil {
+			return e
+		}
+	}
+
+	ce.Mirror = true
+
+	return cli.TouchCacheEntry(cacheDir, ce)
+}
+
+// mirrorEntries Return the entries in the cache of each source, or all of
+// them when there are no sources.
+func mirrorEntries(cacheDir string, sources []string) ([]*cli.CacheEntry, error) {
+	if len(sources) == 0 {
+		return cli.ListCache(cacheDir)
+	}
+
+	entries := []*cli.CacheEntry{}
+	seen := map[string]bool{}
+
+	for _, source := range sources {
+		found, e1 := cli.FindCacheEntries(cacheDir, source)
+		if e1 != nil {
+			return nil, e1
+		}
+
+		for _, ce := range found {
+			if !seen[ce.Name] {
+				seen[ce.Name] = true
+				entries = append(entries, ce)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// fromMirror Check if a template in the cache was imported from a mirror.
+func fromMirror(cacheDir, source string) bool {
+	entries, _ := cli.FindCacheEntries(cacheDir, source)
+
+	for _, ce := range entries {
+		if ce.Mirror {
+			return true
+		}
+	}
+
+	return false
+}
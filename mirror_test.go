@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMirror(tester *testing.T) {
+	fixture := "repo-07"
+	test.TmpSetParentDataDir(TmpDir)
+	remotes := TmpDir + test.PS + "mirror-remotes"
+	tmplPath := test.SetupARepository(fixture, remotes, FixtureDir, test.PS)
+
+	// Clone the template to the cache of a machine on the network.
+	onlineCache, _ := filepath.Abs(TmpDir + test.PS + "mirror-online-cache")
+	_ = os.RemoveAll(onlineCache)
+	_ = os.MkdirAll(onlineCache, cli.DirMode)
+
+	cfg := &cli.Config{Branch: "main", TmplPath: tmplPath, UsrOpts: &cli.UserOptions{CacheDir: onlineCache}}
+	if e := getGitTemplate(cfg); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	file := TmpDir + test.PS + "repo-07.mirror.zip"
+	cfg.SubCmdMirror.Method = "export"
+	cfg.SubCmdMirror.File = file
+	cfg.SubCmdMirror.Sources = []string{tmplPath}
+	out := &bytes.Buffer{}
+
+	if e := runMirror(cfg, out); e != nil {
+		tester.Fatalf("got an unexpected err: %s", e)
+	}
+
+	if !strings.Contains(out.String(), "exported "+tmplPath+" main") {
+		tester.Errorf("got %q, want the template exported", out.String())
+	}
+
+	// Import it to the cache of a machine with no access to the template.
+	offlineCache, _ := filepath.Abs(TmpDir + test.PS + ".tmpltoapp" + test.PS + "cache")
+	cfg = &cli.Config{UsrOpts: &cli.UserOptions{CacheDir: offlineCache}}
+	cfg.SubCmdMirror.Method = "import"
+	cfg.SubCmdMirror.File = file
+
+	// The second import replaces the entries of the first.
+	for i := 0; i < 2; i++ {
+		if e := runMirror(cfg, out); e != nil {
+			tester.Fatalf("got an unexpected err: %s", e)
+		}
+	}
+
+	if !fromMirror(offlineCache, tmplPath) {
+		tester.Errorf("got no entry imported from a mirror for %v", tmplPath)
+	}
+
+	_ = os.RemoveAll(tmplPath)
+
+	// The second run must not go to the network either, using the template
+	// does not forget it came from a mirror.
+	for i := 1; i <= 2; i++ {
+		outPath := fmt.Sprintf("%v%vprocessed%v%v-mirror-%d", TmpDir, test.PS, test.PS, fixture, i)
+		cmd := runMain(tester.Name(), []string{
+			"-answer-path", FixtureDir + test.PS + fixture + "-answers.json",
+			"-tmpl-path", tmplPath,
+			"-out-path", outPath,
+			"-ref", "main",
+		})
+
+		output, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+		if got := cmd.ProcessState.ExitCode(); got != 0 {
+			tester.Fatalf("run %d got %v, want 0: %s", i, got, output)
+		}
+
+		if !stdlib.PathExist(outPath + test.PS + "README.md") {
+			tester.Errorf("run %d got no template processed from the mirror", i)
+		}
+
+		if !fromMirror(offlineCache, tmplPath) {
+			tester.Errorf("run %d forgot the entry was imported from a mirror", i)
+		}
+	}
+}
+
+func TestRunMirrorBadCmd(tester *testing.T) {
+	cfg := &cli.Config{UsrOpts: &cli.UserOptions{CacheDir: TmpDir}}
+	cfg.SubCmdMirror.Method = "sync"
+
+	if e := runMirror(cfg, &bytes.Buffer{}); e == nil {
+		tester.Errorf("got no error, want one")
+	}
+}
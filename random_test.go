@@ -0,0 +1,158 @@
+package main
+
+import (
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSeed(tester *testing.T) {
+	made := make([]string, 2)
+
+	for i := range made {
+		outDir := TmpDir + cli.PS + "app-random-seed-" + string(rune('a'+i))
+		_ = os.RemoveAll(outDir)
+
+		cmd := runMain(tester.Name(), []string{
+			"-default-val", "Web",
+			"-record-secrets",
+			"-seed", "42",
+			"-tmpl-path", FixtureDir + cli.PS + "random-01",
+			"-out-path", outDir,
+			"-tmpl-type", "dir",
+		})
+		out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+		if got := cmd.ProcessState.ExitCode(); got != 0 {
+			tester.Fatalf("got %v, want 0: %s", got, out)
+		}
+
+		env, _ := os.ReadFile(outDir + cli.PS + ".env")
+		made[i] = string(env)
+
+		r, e := cli.ReadRecord(outDir)
+		if e != nil {
+			tester.Fatal(e)
+		}
+		if r.Seed == nil || *r.Seed != 42 {
+			tester.Errorf("got seed %v, want 42", r.Seed)
+		}
+		if len(r.Generated) != 4 {
+			tester.Errorf("got %v generated values, want 4: %v", len(r.Generated), r.Generated)
+		}
+	}
+
+	if made[0] != made[1] {
+		tester.Errorf("the same seed made different values:\n%v\n%v", made[0], made[1])
+	}
+}
+
+func TestRegenerateFromRecord(tester *testing.T) {
+	firstDir := TmpDir + cli.PS + "app-random-first"
+	againDir := TmpDir + cli.PS + "app-random-again"
+	_ = os.RemoveAll(firstDir)
+	_ = os.RemoveAll(againDir)
+
+	args := []string{
+		"-default-val", "Web",
+		"-record-secrets",
+		"-tmpl-path", FixtureDir + cli.PS + "random-01",
+		"-tmpl-type", "dir",
+	}
+
+	// No seed, so it is random and recorded.
+	cmd := runMain(tester.Name(), append(args, "-out-path", firstDir))
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	cmd = runMain(tester.Name(), append(args, "-out-path", againDir, "-answer-path", firstDir+cli.PS+cli.RecordFile))
+	out, _ = test.VerboseSubCmdOut(cmd.CombinedOutput())
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	for _, f := range []string{".env", "README.md"} {
+		want, _ := os.ReadFile(firstDir + cli.PS + f)
+		got, _ := os.ReadFile(againDir + cli.PS + f)
+		if string(got) != string(want) {
+			tester.Errorf("%v: got %q, want %q", f, got, want)
+		}
+	}
+}
+
+func TestRecordWithoutSecrets(tester *testing.T) {
+	outDir := TmpDir + cli.PS + "app-random-no-secrets"
+	_ = os.RemoveAll(outDir)
+
+	cmd := runMain(tester.Name(), []string{
+		"-default-val", "Web",
+		"-seed", "42",
+		"-tmpl-path", FixtureDir + cli.PS + "random-01",
+		"-out-path", outDir,
+		"-tmpl-type", "dir",
+	})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	r, e := cli.ReadRecord(outDir)
+	if e != nil {
+		tester.Fatal(e)
+	}
+
+	if _, ok := r.Generated["password#1"]; ok || r.Seed != nil {
+		tester.Errorf("got the password or seed %v in the record: %v", r.Seed, r.Generated)
+	}
+
+	if _, ok := r.Generated["appId"]; !ok {
+		tester.Errorf("got %v, want the values that are not passwords", r.Generated)
+	}
+
+	if fi, e := os.Stat(outDir + cli.PS + cli.RecordFile); e == nil && runtime.GOOS != "windows" && fi.Mode().Perm() != 0600 {
+		tester.Errorf("got the record mode %v, want 0600", fi.Mode().Perm())
+	}
+}
+
+func TestBatchSeed(tester *testing.T) {
+	outDir := TmpDir + cli.PS + "batch-seed"
+	_ = os.RemoveAll(outDir)
+
+	cmd := runMain(tester.Name(), []string{
+		"-batch", FixtureDir + cli.PS + "batch-01.csv",
+		"-record-secrets",
+		"-seed", "100",
+		"-tmpl-path", FixtureDir + cli.PS + "random-01",
+		"-out-path", outDir + "/{{.clientId}}",
+		"-tmpl-type", "dir",
+	})
+	out, _ := test.VerboseSubCmdOut(cmd.CombinedOutput())
+
+	if got := cmd.ProcessState.ExitCode(); got != 0 {
+		tester.Fatalf("got %v, want 0: %s", got, out)
+	}
+
+	// Each row has its own seed, so no two make the same values.
+	for want, client := range []string{"acme", "globex"} {
+		r, e := cli.ReadRecord(outDir + cli.PS + client)
+		if e != nil {
+			tester.Fatal(e)
+		}
+
+		if r.Seed == nil || *r.Seed != int64(100+want) {
+			tester.Errorf("%v: got seed %v, want %v", client, r.Seed, 100+want)
+		}
+	}
+
+	a, _ := os.ReadFile(outDir + cli.PS + "acme" + cli.PS + ".env")
+	b, _ := os.ReadFile(outDir + cli.PS + "globex" + cli.PS + ".env")
+	if strings.TrimPrefix(string(a), "APP_NAME=Acme") == strings.TrimPrefix(string(b), "APP_NAME=Globex") {
+		tester.Errorf("rows made the same values: %s", a)
+	}
+}
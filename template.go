@@ -1 +1,235 @@
 package main
+
+import (
+	"fmt"
+	"github.com/kohirens/stdlib"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// resolveTemplate Get a template, to the cache when it is not local, check
+// that it can be used and read its template.json. Sets the directory of the
+// template files and its manifest.
+func resolveTemplate(cfg *cli.Config) error {
+	// Stop before anything is downloaded, cloned or extracted.
+	if e := cfg.Policy.Check(cfg.TmplPath); e != nil {
+		return e
+	}
+
+	// Templates imported from a mirror cannot be fetched again.
+	if !cfg.Offline && fromMirror(cfg.UsrOpts.CacheDir, cfg.TmplPath) {
+		infof(cli.Messages.UsingMirror, cli.RedactUrl(cfg.TmplPath))
+		cfg.Offline = true
+	}
+
+	switch cfg.TmplType {
+	case "zip":
+		if e := getZipTemplate(cfg); e != nil {
+			return e
+		}
+	case "git":
+		if e := getGitTemplate(cfg); e != nil {
+			return e
+		}
+	}
+
+	if cfg.TmplType == "dir" && cfg.RequireSignature {
+		return fmt.Errorf(cli.Errors.SignatureImpossible, cfg.TmplType)
+	}
+
+	if !stdlib.DirExist(cfg.Tmpl) {
+		return fmt.Errorf(cli.Errors.InvalidTmplDir, cfg.Tmpl)
+	}
+
+	// Git LFS pointers would be rendered as if they were the files.
+	if pointers, e := cli.FindLfsPointers(cfg.Tmpl); e == nil && len(pointers) > 0 {
+		return fmt.Errorf(cli.Errors.LfsPointers, cfg.Tmpl, strings.Join(pointers, ", "))
+	}
+
+	// Require template directories to have a specific file in order to be processed to prevent processing directories unintentionally.
+	tmplManifestFile := cfg.Tmpl + cli.PS + cli.TmplManifest
+	tmplManifest, errX := cli.ReadTemplateJson(tmplManifestFile)
+	if errX != nil {
+		return fmt.Errorf(cli.Errors.MissingTmplJson, cli.TmplManifest, tmplManifestFile, errX.Error())
+	}
+
+	cfg.TmplJson = tmplManifest
+
+	return nil
+}
+
+// getZipTemplate Download, verify and extract a zip template, setting the
+// directory of the template files. A zip in the cache is locked while it is
+// downloaded and extracted to a snapshot, which other processes never change.
+func getZipTemplate(cfg *cli.Config) error {
+	var zipFile string
+	var iErr error
+
+	cacheDir := cfg.UsrOpts.CacheDir
+	zipFile = cfg.TmplPath
+
+	if cfg.TmplLocation != "remote" {
+		if cfg.Sha256 != "" {
+			iErr = cli.VerifyChecksum(zipFile, cfg.Sha256)
+		}
+
+		if iErr == nil {
+			iErr = cli.VerifySignedTemplate(zipFile, zipFile, cfg.UsrOpts.TrustedKeys, cfg.RequireSignature, nil)
+		}
+
+		if iErr != nil {
+			return iErr
+		}
+
+		cfg.Tmpl, iErr = cli.Extract(zipFile)
+
+		return iErr
+	}
+
+	zipUrl, _ := cli.ParseChecksumFragment(cfg.TmplPath)
+
+	unlock, e1 := cli.LockCacheEntry(cacheDir, cli.DownloadName(zipUrl))
+	if e1 != nil {
+		return e1
+	}
+	defer unlock()
+
+	if cfg.Offline {
+		infof(cli.Messages.OfflineMode)
+		zipFile, iErr = cachedDownload(cacheDir, cfg.TmplPath, cfg.Sha256)
+		if iErr != nil {
+			return iErr
+		}
+
+		touchCache(cacheDir, &cli.CacheEntry{Name: filepath.Base(zipFile), Source: cfg.TmplPath, Type: "zip"})
+
+		// Only a signature next to the cached download can be verified.
+		iErr = cli.VerifySignedTemplate(zipFile, zipFile, cfg.UsrOpts.TrustedKeys, cfg.RequireSignature, nil)
+	} else {
+		client := cli.NewAuthClient(&http.Client{}, cfg.UsrOpts.Credentials)
+		zipFile, iErr = cli.DownloadVerified(cfg.TmplPath, cfg.Sha256, cacheDir, client)
+		if iErr != nil {
+			return iErr
+		}
+
+		touchCache(cacheDir, &cli.CacheEntry{
+			Name:   filepath.Base(zipFile),
+			Source: cfg.TmplPath,
+			Type:   "zip",
+		})
+
+		iErr = cli.VerifySignedTemplate(zipFile, zipUrl, cfg.UsrOpts.TrustedKeys, cfg.RequireSignature, client)
+	}
+
+	if iErr != nil {
+		return iErr
+	}
+
+	cfg.Tmpl, iErr = cli.ExtractSnapshot(zipFile, cacheDir)
+
+	return iErr
+}
+
+// getGitTemplate Clone, or update, a git template in the cache and set the
+// directory of the template files, the ref and commit. The clone is locked
+// while it is changed and copied to a snapshot of the commit, which other
+// processes never change.
+func getGitTemplate(cfg *cli.Config) error {
+	var repo, commitHash string
+	var err2 error
+
+	if e := setupGit(cfg); e != nil {
+		return e
+	}
+
+	if cfg.Offline {
+		infof(cli.Messages.OfflineMode)
+	}
+
+	cacheDir := cfg.UsrOpts.CacheDir
+
+	if cfg.Branch == cli.LatestVersion || cfg.TmplVersion != "" {
+		var tag string
+		var e3 error
+		if cfg.Offline {
+			// Pick from the versions in the cache.
+			tag, e3 = cli.PickVersion(cachedRefs(cacheDir, cfg.TmplPath), cfg.TmplVersion, cfg.Pre)
+		} else {
+			tag, e3 = getVersionTag(cfg.TmplPath, cfg.TmplVersion, cfg.Pre)
+		}
+		if e3 != nil {
+			return e3
+		}
+		infof(cli.Messages.PickedVersion, tag)
+		cfg.Branch = tag
+	}
+
+	// Determine the cache location
+	name := getRepoDir(cfg.TmplPath, cfg.Branch)
+	repoDir := cacheDir + cli.PS + name
+	infof(cli.Messages.OutRepoDir, repoDir)
+
+	unlock, e1 := cli.LockCacheEntry(cacheDir, name)
+	if e1 != nil {
+		return e1
+	}
+	defer unlock()
+
+	migrateRepoDir(cacheDir, cfg.TmplPath, cfg.Branch, repoDir)
+
+	// Do a pull when the repo already exists. This will fail if it downloaded a zip.
+	cached := stdlib.DirExist(repoDir + cli.PS + gitConfDir)
+	switch {
+	case cached && cfg.Offline:
+		infof(cli.Messages.UsingCache, repoDir)
+		repo, commitHash, err2 = gitCheckoutCached(repoDir, cfg.Branch)
+	case cached:
+		infof(cli.Messages.UsingCache, repoDir)
+		repo, commitHash, err2 = gitCheckout(repoDir, cfg.Branch)
+	case cfg.Offline:
+		return notCachedError(cacheDir, cfg.TmplPath, cfg.Branch)
+	default:
+		infof(cli.Messages.CloningToCache, repoDir)
+		repo, commitHash, err2 = gitClone(cfg.TmplPath, repoDir, cfg.Branch)
+	}
+
+	infof(cli.Messages.RepoInfo, repo, commitHash)
+	if err2 != nil {
+		return err2
+	}
+
+	// A snapshot with submodules is not the same as one without.
+	version := commitHash
+	if cfg.RecurseSubmodules {
+		if e := gitSubmodules(repo, cfg.Offline); e != nil {
+			return e
+		}
+		version += "-submodules"
+	}
+
+	gitLfsPull(repo, cfg.Offline)
+
+	if e := gitVerify(repo, cfg.Branch, cfg.RequireSignature); e != nil {
+		return e
+	}
+
+	touchCache(cacheDir, &cli.CacheEntry{
+		Commit: commitHash,
+		Name:   name,
+		Ref:    cfg.Branch,
+		Source: cfg.TmplPath,
+		Type:   "git",
+	})
+
+	snapshot, e2 := cli.Snapshot(repo, cli.SnapshotPath(cacheDir, name, version))
+	if e2 != nil {
+		return e2
+	}
+
+	cfg.Tmpl = snapshot
+	cfg.TmplCommit = commitHash
+
+	return nil
+}
@@ -11,6 +11,89 @@ example: {{ .appName }} -answers "answers.json" -out-path "/tmp/new-app" "https:
 Options:
 `
 
+var usageAlias = `{{define "option"}}{{end}}
+Manage short names for templates, kept in registry.json in the app data directory.
+
+Usage: {{.appName}} alias list
+       {{.appName}} alias add [options] <name> <tmpl-path>
+       {{.appName}} alias remove <name>
+
+  list                     List the aliases.
+  add <name> <tmpl-path>   Add, or replace, an alias. A name has letters, digits, "-" and "_".
+  remove <name>            Remove an alias.
+
+Options:
+  -answer-path <file>      Answer file used when -answer-path is not given.
+  -ref <ref>               Ref pinned for the template, used when -ref is not given.
+  -tmpl-type <type>        Type of template (git, zip or dir), used when -tmpl-type is not given.
+
+example: {{.appName}} alias add -ref 2.1.0 web "https://github.com/kohirens/tmpl-go-web.git"
+         {{.appName}} web ./my-app
+`
+
+var usageAnswers = `{{define "option"}}{{end}}
+Make an answer file with every placeholder of a template, and its prompt under
+"$comment". The template is downloaded or cloned to the cache like a normal run.
+
+Usage: {{.appName}} answers init [options] <tmpl-path>
+
+Options:
+  -merge                   Update the answer file when it exists; add missing placeholders and flag obsolete ones.
+  -o <file>                Answer file to write, answers.json by default.
+  -ref <ref>               Branch, tag, full ref or commit of a git template.
+  -tmpl-type <type>        Type of template; git (the default), zip or dir.
+  -tmpl-version <constraint>
+                           Semantic version constraint for the tag of a git template.
+
+example: {{.appName}} answers init -o web-answers.json "https://github.com/kohirens/tmpl-go-web.git"
+`
+
+var usageCache = `{{define "option"}}{{end}}
+Manage the templates downloaded or cloned to the cache.
+
+Usage: {{.appName}} cache list|info|prune|clear|path [options] [<tmpl-path>]
+
+  list                     List the templates in the cache, the most recently used first.
+  info <tmpl-path>         Show the cache entries of a template.
+  prune                    Remove templates by age (-older-than) or to fit a size (-max-size).
+  clear [<tmpl-path>]      Remove a template from the cache, or everything when none is given.
+  path                     Print the location of the cache.
+
+Options:
+  -max-size <size>         Remove the least recently used templates until the cache is no bigger than this, such as 500MB or 2GB.
+  -older-than <age>        Remove templates not used for longer than this, such as 30d or 12h.
+
+example: {{.appName}} cache prune -older-than 30d -max-size 1GB
+`
+
+var usageInitTemplate = `{{define "option"}}{{end}}
+Make a new template to start from; a template.json, a sample file, a
+.tmpltoappignore, answers to test it with and README stubs.
+
+Usage: {{.appName}} init-template [options] <dir>
+
+Options:
+  -from <dir>              Start from an existing project, its placeholders are added to template.json.
+
+example: {{.appName}} init-template -from ./my-app ./tmpl-my-app
+`
+
+var usageInspect = `{{define "option"}}{{end}}
+Show what a template needs, from its template.json, without generating anything.
+The template is downloaded or cloned to the cache like a normal run.
+
+Usage: {{.appName}} inspect [options] <tmpl-path>
+
+Options:
+  -format <format>         Print as text (the default) or json.
+  -ref <ref>               Branch, tag, full ref or commit of a git template.
+  -tmpl-type <type>        Type of template; git (the default), zip or dir.
+  -tmpl-version <constraint>
+                           Semantic version constraint for the tag of a git template.
+
+example: {{.appName}} inspect -format json "https://github.com/kohirens/tmpl-go-web.git"
+`
+
 var usageManifest = `
 Generate a template.json in the {{.appName}} schema format containing all the specified templates placeholders.
 This is meant for template designers to reduce error in adding placeholders to your file manually.
@@ -20,3 +103,45 @@ Usage: {{.appName}} <path>
 
 example: {{.appName}} ./
 `
+
+var usageMirror = `{{define "option"}}{{end}}
+Move templates to machines with no access to where they came from.
+
+Usage: {{.appName}} mirror export <file> [<tmpl-path>...]
+       {{.appName}} mirror import <file>
+
+  export <file> [<tmpl-path>...]
+                           Pack the templates in the cache, or only those given, into one archive.
+                           Git templates are packed as git bundles, zip templates with their metadata.
+  import <file>            Put the templates in an archive into the cache, they are then used
+                           without going to the network.
+
+example: {{.appName}} mirror export templates.mirror.zip "https://github.com/kohirens/tmpl-go-web.git"
+`
+
+var usageTest = `{{define "option"}}{{end}}
+Test a template by rendering each test case, a directory in tests/ with an
+answers.json, and comparing it to the expected/ output of the case. tests/ must
+be listed in .tmpltoappignore.
+
+Usage: {{.appName}} test [options] <tmpl-dir>
+
+Options:
+  -update                  Save the output of each test case as what it expects.
+
+example: {{.appName}} test -update ./tmpl-my-app
+`
+
+var usageVersions = `{{define "option"}}{{end}}
+List the versions (semantic version tags) of a git template, the highest first.
+The version that would be used is marked with a "*".
+
+Usage: {{.appName}} versions [-pre] [-tmpl-version <constraint>] <tmpl-path>
+
+Options:
+  -pre                     Include pre-release versions.
+  -tmpl-version <constraint>
+                           Semantic version constraint, such as "^2.1", "~1.4" or ">=1.0 <2.0".
+
+example: {{.appName}} versions -tmpl-version "^2.1" "https://github.com/kohirens/tmpl-go-web.git"
+`
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"io"
+)
+
+// printVersions Print the versions of a git template, the highest first. The
+// version picked by the -tmpl-version constraint (or the latest) is marked
+// with a "*".
+func printVersions(cfg *cli.Config, w io.Writer) error {
+	if e := cfg.Policy.Check(cfg.TmplPath); e != nil {
+		return e
+	}
+
+	if e := setupGit(cfg); e != nil {
+		return e
+	}
+
+	tags, e1 := getRemoteTags(cfg.TmplPath)
+	if e1 != nil {
+		return e1
+	}
+
+	// List them all, even when none satisfy the constraint.
+	pick, e2 := cli.PickVersion(tags, cfg.TmplVersion, cfg.Pre)
+
+	for _, v := range cli.SemverTags(tags, cfg.Pre) {
+		marker := "  "
+		if v.Original() == pick {
+			marker = "* "
+		}
+		if _, e := fmt.Fprintf(w, "%v%v\n", marker, v.Original()); e != nil {
+			return e
+		}
+	}
+
+	return e2
+}
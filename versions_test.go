@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"github.com/kohirens/tmpltoapp/internal/cli"
+	"github.com/kohirens/tmpltoapp/internal/test"
+	"testing"
+)
+
+func TestPrintVersions(tester *testing.T) {
+	var testCases = []struct {
+		name       string
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{"latest", "", "* 1.0.0\n  0.2.0\n  0.1.1\n  0.1.0\n", false},
+		{"constraint", "~0.1", "  1.0.0\n  0.2.0\n* 0.1.1\n  0.1.0\n", false},
+		{"noMatch", "^2", "  1.0.0\n  0.2.0\n  0.1.1\n  0.1.0\n", true},
+	}
+
+	repoPath := test.SetupARepository("repo-04", TmpDir, FixtureDir, cli.PS)
+
+	for _, tc := range testCases {
+		tester.Run(tc.name, func(t *testing.T) {
+			cfg := &cli.Config{TmplPath: repoPath, TmplVersion: tc.constraint, UsrOpts: &cli.UserOptions{}}
+			out := &bytes.Buffer{}
+
+			err := printVersions(cfg, out)
+
+			if tc.wantErr != (err != nil) {
+				t.Errorf("got error %v, want an error %v", err, tc.wantErr)
+			}
+
+			if out.String() != tc.want {
+				t.Errorf("got %q, want %q", out.String(), tc.want)
+			}
+		})
+	}
+}